@@ -0,0 +1,69 @@
+package graph
+
+// adjacentEdge is an edge that can be followed from a node along with the node it leads to.
+type adjacentEdge struct {
+	Edge Edge
+	To   NodeID
+}
+
+// otherEndpoint returns the endpoint of an edge that is not id.
+// For a self loop this is id itself.
+func otherEndpoint(edge Edge, id NodeID) (NodeID, error) {
+	nodes, err := edge.GetNodes()
+	if err != nil {
+		return id, err
+	}
+	for _, node := range nodes {
+		if node.GetID() != id {
+			return node.GetID(), nil
+		}
+	}
+	return id, nil
+}
+
+// otherEndpointID is otherEndpoint for callers that only need the id, so the graph is not looked up.
+func otherEndpointID(edge Edge, id NodeID) NodeID {
+	if edge.GetID().From == id {
+		return edge.GetID().To
+	}
+	return edge.GetID().From
+}
+
+// getAdjacentEdges returns the edges that can be followed from node.
+// In a directed graph these are the outgoing edges, or the incoming edges if reverse is true.
+// In an undirected graph these are the incident edges.
+// The edges keep the order of the Node method they come from.
+func getAdjacentEdges(node Node, isDirected bool, reverse bool) ([]adjacentEdge, error) {
+	adjacent := make([]adjacentEdge, 0)
+	if !isDirected {
+		edges, err := node.GetIncidentEdges()
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range edges {
+			to, err := otherEndpoint(edge, node.GetID())
+			if err != nil {
+				return nil, err
+			}
+			adjacent = append(adjacent, adjacentEdge{Edge: edge, To: to})
+		}
+		return adjacent, nil
+	}
+
+	getEdges, getOther := node.GetOutgoingEdges, Edge.GetTo
+	if reverse {
+		getEdges, getOther = node.GetIncomingEdges, Edge.GetFrom
+	}
+	edges, err := getEdges()
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range edges {
+		other, err := getOther(edge)
+		if err != nil {
+			return nil, err
+		}
+		adjacent = append(adjacent, adjacentEdge{Edge: edge, To: other.GetID()})
+	}
+	return adjacent, nil
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func adjacentNodeIDs(adjacent []adjacentEdge) []NodeID {
+	ids := make([]NodeID, 0)
+	for _, next := range adjacent {
+		ids = append(ids, next.To)
+	}
+	return ids
+}
+
+func Test_GetAdjacentEdges_Directed(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true, AllowRedundantEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2)
+	gb.AddEdge(3, 1)
+	gb.AddEdge(1, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+
+	adjacent, err := getAdjacentEdges(node, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2}, adjacentNodeIDs(adjacent))
+
+	adjacent, err = getAdjacentEdges(node, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 3}, adjacentNodeIDs(adjacent))
+}
+
+func Test_GetAdjacentEdges_Undirected(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowRedundantEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2)
+	gb.AddEdge(3, 1)
+	gb.AddEdge(1, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+
+	adjacent, err := getAdjacentEdges(node, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3}, adjacentNodeIDs(adjacent))
+}
@@ -0,0 +1,124 @@
+package graph
+
+// LevelFunction is a search hook called with the depth of a breadth first search level and the nodes in it.
+// If it returns an error, the search stops and returns that error.
+type LevelFunction func(depth int, nodes []Node) (TraversalControl, error)
+
+// BreadthFirstSearchOptions configures BreadthFirstSearch.
+type BreadthFirstSearchOptions struct {
+	// Sources are the nodes the search starts from, all at depth 0.
+	// If empty, every node not reached yet starts a new search in ascending id order.
+	Sources []Node
+	// MaxDepth stops the search from following edges of nodes this many edges away from the sources,
+	// so a MaxDepth of 0 only visits the sources. Nil means there is no limit and a negative depth is an error.
+	MaxDepth     *int
+	ReverseGraph bool
+	IsUndirected bool
+	// VisitNode is called once for every node reached, in the order they are reached.
+	// Returning SkipChildren leaves the node's neighbors unexplored.
+	VisitNode NodeFunction
+	// BeforeLevel is called before the nodes of a level are visited.
+	// Returning SkipChildren leaves the neighbors of the whole level unexplored.
+	BeforeLevel LevelFunction
+	AfterLevel  LevelFunction
+}
+
+// BreadthFirstSearchResult holds how far every reached node is from the sources.
+type BreadthFirstSearchResult struct {
+	// Depths maps every reached node to the number of edges between it and the closest source.
+	Depths map[NodeID]int
+	// Parents maps every reached node except the sources to the edge it was reached through.
+	Parents map[NodeID]Edge
+}
+
+func callLevelFunction(hook LevelFunction, depth int, nodes []Node) (TraversalControl, error) {
+	if hook == nil {
+		return Continue, nil
+	}
+	return hook(depth, nodes)
+}
+
+func breadthFirstSearchHelper(g Graph, sources []Node, result BreadthFirstSearchResult, options BreadthFirstSearchOptions) (TraversalControl, error) {
+	level := make([]Node, 0)
+	for _, source := range sources {
+		if _, reached := result.Depths[source.GetID()]; !reached {
+			result.Depths[source.GetID()] = 0
+			level = append(level, source)
+		}
+	}
+	for depth := 0; len(level) > 0; depth++ {
+		levelControl, err := callLevelFunction(options.BeforeLevel, depth, level)
+		if err != nil || levelControl == Stop {
+			return Stop, err
+		}
+		nextLevel := make([]Node, 0)
+		for _, node := range level {
+			control, err := callNodeFunction(options.VisitNode, node)
+			if err != nil || control == Stop {
+				return Stop, err
+			}
+			if control == SkipChildren || levelControl == SkipChildren {
+				continue
+			}
+			if options.MaxDepth != nil && depth >= *options.MaxDepth {
+				continue
+			}
+			adjacent, err := getAdjacentEdges(node, !options.IsUndirected, options.ReverseGraph)
+			if err != nil {
+				return Stop, err
+			}
+			for _, next := range adjacent {
+				if _, reached := result.Depths[next.To]; reached {
+					continue
+				}
+				nextNode, err := g.GetNode(next.To)
+				if err != nil {
+					return Stop, err
+				}
+				result.Depths[next.To] = depth + 1
+				result.Parents[next.To] = next.Edge
+				nextLevel = append(nextLevel, nextNode)
+			}
+		}
+		control, err := callLevelFunction(options.AfterLevel, depth, level)
+		if err != nil || control == Stop {
+			return Stop, err
+		}
+		level = nextLevel
+	}
+	return Continue, nil
+}
+
+// BreadthFirstSearch visits the nodes of g level by level, starting from the sources.
+// Directed graphs follow outgoing edges (incoming edges if ReverseGraph is set)
+// and undirected graphs follow incident edges.
+// Nodes in the same level are visited in the order they were reached,
+// which follows the sorted edge order of each node.
+// It returns the first error from the graph or a hook along with the nodes reached so far.
+func BreadthFirstSearch(g Graph, options BreadthFirstSearchOptions) (BreadthFirstSearchResult, error) {
+	if !g.IsDirected() {
+		options.IsUndirected = true
+	}
+	result := BreadthFirstSearchResult{
+		Depths:  make(map[NodeID]int),
+		Parents: make(map[NodeID]Edge),
+	}
+	if options.MaxDepth != nil && *options.MaxDepth < 0 {
+		return result, NegativeMaxDepthError{MaxDepth: *options.MaxDepth}
+	}
+	if len(options.Sources) > 0 {
+		_, err := breadthFirstSearchHelper(g, options.Sources, result, options)
+		return result, err
+	}
+	allNodes, err := g.GetNodes()
+	if err != nil {
+		return result, err
+	}
+	for _, node := range allNodes {
+		control, err := breadthFirstSearchHelper(g, []Node{node}, result, options)
+		if err != nil || control == Stop {
+			return result, err
+		}
+	}
+	return result, nil
+}
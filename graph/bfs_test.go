@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildBreadthFirstGraph builds the tree 1 -> {2, 3}, 2 -> 4, 3 -> 5, 5 -> 6 and the lone node 7.
+func buildBreadthFirstGraph(t *testing.T, bo BuilderOptions) Graph {
+	gb := NewGraphBuilder(bo)
+	for i := 1; i < 8; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(1, 3)
+	gb.AddEdge(2, 4)
+	gb.AddEdge(3, 5)
+	gb.AddEdge(5, 6)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func Test_BreadthFirstSearch_Levels(t *testing.T) {
+	graph := buildBreadthFirstGraph(t, BuilderOptions{IsDirected: true})
+	source, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	visited := make([]NodeID, 0)
+	levels := make([][]NodeID, 0)
+	result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		Sources: []Node{source},
+		VisitNode: func(n Node) (TraversalControl, error) {
+			visited = append(visited, n.GetID())
+			return Continue, nil
+		},
+		BeforeLevel: func(depth int, nodes []Node) (TraversalControl, error) {
+			assert.Equal(t, len(levels), depth)
+			levels = append(levels, nodeIDs(nodes))
+			return Continue, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3, 4, 5, 6}, visited)
+	assert.Equal(t, [][]NodeID{{1}, {2, 3}, {4, 5}, {6}}, levels)
+	assert.Equal(t, map[NodeID]int{1: 0, 2: 1, 3: 1, 4: 2, 5: 2, 6: 3}, result.Depths)
+	assert.Equal(t, EdgeID{From: 5, To: 6}, result.Parents[6].GetID())
+	assert.NotContains(t, result.Parents, NodeID(1))
+}
+
+func Test_BreadthFirstSearch_MaxDepth(t *testing.T) {
+	graph := buildBreadthFirstGraph(t, BuilderOptions{})
+	source, err := graph.GetNode(3)
+	assert.NoError(t, err)
+	maxDepth := 1
+	result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		Sources:  []Node{source},
+		MaxDepth: &maxDepth,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[NodeID]int{3: 0, 1: 1, 5: 1}, result.Depths)
+
+	maxDepth = 0
+	result, err = BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		Sources:  []Node{source},
+		MaxDepth: &maxDepth,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[NodeID]int{3: 0}, result.Depths)
+
+	maxDepth = -1
+	_, err = BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		Sources:  []Node{source},
+		MaxDepth: &maxDepth,
+	})
+	assert.ErrorIs(t, err, NegativeMaxDepthError{MaxDepth: -1})
+}
+
+func Test_BreadthFirstSearch_MultipleSources(t *testing.T) {
+	graph := buildBreadthFirstGraph(t, BuilderOptions{})
+	first, err := graph.GetNode(4)
+	assert.NoError(t, err)
+	second, err := graph.GetNode(6)
+	assert.NoError(t, err)
+	result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		Sources: []Node{first, second},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[NodeID]int{4: 0, 6: 0, 2: 1, 5: 1, 1: 2, 3: 2}, result.Depths)
+}
+
+func Test_BreadthFirstSearch_ReverseGraph(t *testing.T) {
+	graph := buildBreadthFirstGraph(t, BuilderOptions{IsDirected: true})
+	source, err := graph.GetNode(6)
+	assert.NoError(t, err)
+	result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		Sources:      []Node{source},
+		ReverseGraph: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[NodeID]int{6: 0, 5: 1, 3: 2, 1: 3}, result.Depths)
+}
+
+func Test_BreadthFirstSearch_AllNodes(t *testing.T) {
+	graph := buildBreadthFirstGraph(t, BuilderOptions{IsDirected: true})
+	levels := make([][]NodeID, 0)
+	result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		AfterLevel: func(depth int, nodes []Node) (TraversalControl, error) {
+			levels = append(levels, nodeIDs(nodes))
+			return Continue, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]NodeID{{1}, {2, 3}, {4, 5}, {6}, {7}}, levels)
+	assert.Len(t, result.Depths, 7)
+}
+
+func Test_BreadthFirstSearch_Control(t *testing.T) {
+	graph := buildBreadthFirstGraph(t, BuilderOptions{IsDirected: true})
+	source, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		Sources: []Node{source},
+		VisitNode: func(n Node) (TraversalControl, error) {
+			if n.GetID() == 3 {
+				return SkipChildren, nil
+			}
+			if n.GetID() == 4 {
+				return Stop, nil
+			}
+			return Continue, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[NodeID]int{1: 0, 2: 1, 3: 1, 4: 2}, result.Depths)
+
+	hookError := errors.New("hook error")
+	_, err = BreadthFirstSearch(graph, BreadthFirstSearchOptions{
+		AfterLevel: func(depth int, nodes []Node) (TraversalControl, error) {
+			return Continue, hookError
+		},
+	})
+	assert.ErrorIs(t, err, hookError)
+}
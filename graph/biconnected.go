@@ -0,0 +1,140 @@
+package graph
+
+import "sort"
+
+// BiconnectedComponent is a maximal set of nodes that stays connected when any one of its nodes is removed.
+// Two nodes joined by a bridge also form a biconnected component.
+type BiconnectedComponent []Node
+
+// biconnectivity is everything found by a single depth first search over an undirected graph.
+type biconnectivity struct {
+	articulationPoints []Node
+	bridges            []Edge
+	components         []BiconnectedComponent
+}
+
+// findBiconnectivity uses the discovery order of a depth first search along with the lowest discovery
+// index reachable from each subtree through a single back edge (Hopcroft-Tarjan).
+func findBiconnectivity(g Graph, methodName string) (biconnectivity, error) {
+	if g.IsDirected() {
+		return biconnectivity{}, CannotUseForDirectedGraphError{MethodName: methodName}
+	}
+	result := biconnectivity{
+		articulationPoints: make([]Node, 0),
+		bridges:            make([]Edge, 0),
+		components:         make([]BiconnectedComponent, 0),
+	}
+	index := make(map[NodeID]int)
+	lowLink := make(map[NodeID]int)
+	parentEdges := make(map[NodeID]Edge)
+	children := make(map[NodeID]int)
+	isArticulationPoint := make(map[NodeID]bool)
+	// path holds the nodes currently being explored and edges the edges not yet assigned to a component
+	path := make([]Node, 0)
+	edges := make([]Edge, 0)
+
+	_, err := DepthFirstSearch(g, DepthFirstSearchOptions{
+		BeforeRecursion: func(n Node) (TraversalControl, error) {
+			index[n.GetID()] = len(index)
+			lowLink[n.GetID()] = index[n.GetID()]
+			path = append(path, n)
+			return Continue, nil
+		},
+		TreeEdge: func(e Edge) (TraversalControl, error) {
+			from := path[len(path)-1].GetID()
+			parentEdges[otherEndpointID(e, from)] = e
+			children[from]++
+			edges = append(edges, e)
+			return Continue, nil
+		},
+		BackEdge: func(e Edge) (TraversalControl, error) {
+			from := path[len(path)-1].GetID()
+			to := otherEndpointID(e, from)
+			if to == from {
+				return Continue, nil
+			}
+			if index[to] < lowLink[from] {
+				lowLink[from] = index[to]
+			}
+			edges = append(edges, e)
+			return Continue, nil
+		},
+		AfterRecursion: func(n Node) (TraversalControl, error) {
+			id := n.GetID()
+			path = path[:len(path)-1]
+			if len(path) == 0 {
+				return Continue, nil
+			}
+			parent := path[len(path)-1]
+			parentID := parent.GetID()
+			if lowLink[id] < lowLink[parentID] {
+				lowLink[parentID] = lowLink[id]
+			}
+			if lowLink[id] > index[parentID] {
+				result.bridges = append(result.bridges, parentEdges[id])
+			}
+			if lowLink[id] < index[parentID] {
+				return Continue, nil
+			}
+			// the parent separates this subtree from the rest of the graph
+			isRoot := len(path) == 1
+			if (!isRoot || children[parentID] > 1) && !isArticulationPoint[parentID] {
+				isArticulationPoint[parentID] = true
+				result.articulationPoints = append(result.articulationPoints, parent)
+			}
+			members := make(map[NodeID]bool)
+			component := make(BiconnectedComponent, 0)
+			for {
+				edge := edges[len(edges)-1]
+				edges = edges[:len(edges)-1]
+				for _, endpoint := range []NodeID{edge.GetID().From, edge.GetID().To} {
+					if !members[endpoint] {
+						members[endpoint] = true
+						node, err := g.GetNode(endpoint)
+						if err != nil {
+							return Stop, err
+						}
+						component = append(component, node)
+					}
+				}
+				if edge.GetID() == parentEdges[id].GetID() {
+					break
+				}
+			}
+			result.components = append(result.components, component)
+			return Continue, nil
+		},
+	})
+	if err != nil {
+		return biconnectivity{}, err
+	}
+	sortNodes(result.articulationPoints)
+	sort.Slice(result.bridges, func(i, j int) bool {
+		return lessEdgeID(result.bridges[i].GetID(), result.bridges[j].GetID())
+	})
+	sortComponents(result.components)
+	return result, nil
+}
+
+// ArticulationPoints returns the nodes of an undirected graph whose removal would split their connected component,
+// sorted by id.
+func ArticulationPoints(g Graph) ([]Node, error) {
+	result, err := findBiconnectivity(g, "ArticulationPoints")
+	return result.articulationPoints, err
+}
+
+// Bridges returns the edges of an undirected graph whose removal would split their connected component,
+// sorted by id. An edge with a parallel edge is never a bridge.
+func Bridges(g Graph) ([]Edge, error) {
+	result, err := findBiconnectivity(g, "Bridges")
+	return result.bridges, err
+}
+
+// BiconnectedComponents splits the edges of an undirected graph into biconnected components
+// and returns the nodes of each. Articulation points belong to more than one component,
+// and nodes with no edges other than self loops belong to none.
+// The components are sorted the same way as the ones returned by Kosaraju.
+func BiconnectedComponents(g Graph) ([]BiconnectedComponent, error) {
+	result, err := findBiconnectivity(g, "BiconnectedComponents")
+	return result.components, err
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildBiconnectedGraph(t *testing.T) Graph {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true, AllowRedundantEdges: true})
+	for i := 1; i < 11; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	// triangle 1, 2, 3 joined to triangle 4, 5, 6 by the bridge 3 - 4
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 1)
+	gb.AddEdge(3, 4)
+	gb.AddEdge(4, 5)
+	gb.AddEdge(5, 6)
+	gb.AddEdge(6, 4)
+	// bridge 6 - 7 to a node with a self loop
+	gb.AddEdge(6, 7)
+	gb.AddEdge(7, 7)
+	// parallel edges are never bridges
+	gb.AddEdge(9, 10)
+	gb.AddEdge(9, 10)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func Test_ArticulationPoints(t *testing.T) {
+	graph := buildBiconnectedGraph(t)
+	actual_nodes, err := ArticulationPoints(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{3, 4, 6}, nodeIDs(actual_nodes))
+}
+
+func Test_ArticulationPoints_Root(t *testing.T) {
+	// the root of the search is only an articulation point if it has more than one child
+	gb := NewGraphBuilder()
+	for i := 1; i < 4; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(1, 3)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_nodes, err := ArticulationPoints(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1}, nodeIDs(actual_nodes))
+
+	gb = NewGraphBuilder()
+	for i := 1; i < 4; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	graph, err = gb.Build()
+	assert.NoError(t, err)
+	actual_nodes, err = ArticulationPoints(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{2}, nodeIDs(actual_nodes))
+}
+
+func Test_Bridges(t *testing.T) {
+	graph := buildBiconnectedGraph(t)
+	actual_edges, err := Bridges(graph)
+	assert.NoError(t, err)
+	actual_ids := make([]EdgeID, 0)
+	for _, edge := range actual_edges {
+		actual_ids = append(actual_ids, edge.GetID())
+	}
+	assert.Equal(t, []EdgeID{{From: 3, To: 4}, {From: 6, To: 7}}, actual_ids)
+}
+
+func Test_BiconnectedComponents(t *testing.T) {
+	graph := buildBiconnectedGraph(t)
+	actual_components, err := BiconnectedComponents(graph)
+	assert.NoError(t, err)
+	actual_ids := make([][]NodeID, 0)
+	for _, component := range actual_components {
+		actual_ids = append(actual_ids, nodeIDs(component))
+	}
+	assert.Equal(t, [][]NodeID{{3, 4}, {6, 7}, {9, 10}, {1, 2, 3}, {4, 5, 6}}, actual_ids)
+}
+
+func Test_Biconnectivity_Directed(t *testing.T) {
+	graph := buildWeightedGraph(t, BuilderOptions{IsDirected: true})
+	_, err := ArticulationPoints(graph)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "ArticulationPoints"})
+	_, err = Bridges(graph)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "Bridges"})
+	_, err = BiconnectedComponents(graph)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "BiconnectedComponents"})
+}
@@ -0,0 +1,192 @@
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// Bipartition splits the nodes of a graph into two sides so that every edge joins nodes on different sides.
+type Bipartition struct {
+	// Left holds the sorted ids of the nodes on the same side as the smallest id of their connected component.
+	Left []NodeID
+	// Right holds the sorted ids of all other nodes.
+	Right []NodeID
+}
+
+// IsBipartite splits the nodes of an undirected graph into two sides with no edge between nodes on the same side.
+// If that is impossible then this returns an OddCycleError holding a cycle with an odd number of nodes,
+// which proves the graph is not bipartite.
+func IsBipartite(g Graph) (Bipartition, error) {
+	if g.IsDirected() {
+		return Bipartition{}, CannotUseForDirectedGraphError{MethodName: "IsBipartite"}
+	}
+	// breadth first search levels alternate between the two sides
+	search, err := BreadthFirstSearch(g, BreadthFirstSearchOptions{})
+	if err != nil {
+		return Bipartition{}, err
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return Bipartition{}, err
+	}
+	for _, edge := range edges {
+		id := edge.GetID()
+		if search.Depths[id.From]%2 == search.Depths[id.To]%2 {
+			return Bipartition{}, OddCycleError{NodeIDs: treeCycle(search.Parents, id.From, id.To)}
+		}
+	}
+
+	bipartition := Bipartition{Left: make([]NodeID, 0), Right: make([]NodeID, 0)}
+	for id, depth := range search.Depths {
+		if depth%2 == 0 {
+			bipartition.Left = append(bipartition.Left, id)
+		} else {
+			bipartition.Right = append(bipartition.Right, id)
+		}
+	}
+	sortNodeIDs(bipartition.Left)
+	sortNodeIDs(bipartition.Right)
+	return bipartition, nil
+}
+
+// treeCycle returns the cycle formed by the search tree paths from a and b to their closest common ancestor
+// and an edge between a and b. It starts with its smallest id.
+func treeCycle(parents map[NodeID]Edge, a, b NodeID) []NodeID {
+	fromA := []NodeID{a}
+	positions := map[NodeID]int{a: 0}
+	for current := a; ; {
+		parent, ok := parents[current]
+		if !ok {
+			break
+		}
+		current = otherEndpointID(parent, current)
+		positions[current] = len(fromA)
+		fromA = append(fromA, current)
+	}
+	fromB := make([]NodeID, 0)
+	current := b
+	for {
+		if _, onPath := positions[current]; onPath {
+			break
+		}
+		fromB = append(fromB, current)
+		parent := parents[current]
+		current = otherEndpointID(parent, current)
+	}
+
+	cycle := append([]NodeID{}, fromA[:positions[current]+1]...)
+	for i := len(fromB) - 1; i >= 0; i-- {
+		cycle = append(cycle, fromB[i])
+	}
+	smallest := 0
+	for i := range cycle {
+		if cycle[i] < cycle[smallest] {
+			smallest = i
+		}
+	}
+	return append(cycle[smallest:], cycle[:smallest]...)
+}
+
+// Matching is a set of edges of which no two share a node.
+type Matching struct {
+	// Edges are sorted by id.
+	Edges []Edge
+	// Pairs maps every matched node to the node it is matched with, in both directions.
+	Pairs map[NodeID]NodeID
+}
+
+// MaximumBipartiteMatching finds a matching with as many edges as possible in a bipartite undirected graph
+// using the Hopcroft-Karp algorithm. If the graph is not bipartite then this returns the error from IsBipartite.
+func MaximumBipartiteMatching(g Graph) (Matching, error) {
+	if g.IsDirected() {
+		return Matching{}, CannotUseForDirectedGraphError{MethodName: "MaximumBipartiteMatching"}
+	}
+	bipartition, err := IsBipartite(g)
+	if err != nil {
+		return Matching{}, err
+	}
+	adjacency := make(map[NodeID][]adjacentEdge)
+	for _, id := range bipartition.Left {
+		node, err := g.GetNode(id)
+		if err != nil {
+			return Matching{}, err
+		}
+		adjacency[id], err = getAdjacentEdges(node, false, false)
+		if err != nil {
+			return Matching{}, err
+		}
+	}
+
+	matchedEdges := make(map[NodeID]Edge)
+	pairs := make(map[NodeID]NodeID)
+	distances := make(map[NodeID]int)
+	// freeDistance is the distance of the closest left node with an edge to an unmatched right node
+	// plus one, the length of the shortest augmenting paths
+	freeDistance := math.MaxInt
+
+	buildLayers := func() bool {
+		queue := make([]NodeID, 0)
+		for _, id := range bipartition.Left {
+			if _, matched := pairs[id]; matched {
+				distances[id] = math.MaxInt
+			} else {
+				distances[id] = 0
+				queue = append(queue, id)
+			}
+		}
+		freeDistance = math.MaxInt
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if distances[current]+1 >= freeDistance {
+				continue
+			}
+			for _, next := range adjacency[current] {
+				partner, matched := pairs[next.To]
+				if !matched {
+					freeDistance = distances[current] + 1
+				} else if distances[partner] == math.MaxInt {
+					distances[partner] = distances[current] + 1
+					queue = append(queue, partner)
+				}
+			}
+		}
+		return freeDistance != math.MaxInt
+	}
+
+	var augment func(id NodeID) bool
+	augment = func(id NodeID) bool {
+		for _, next := range adjacency[id] {
+			partner, matched := pairs[next.To]
+			if (!matched && distances[id]+1 == freeDistance) ||
+				(matched && distances[partner] == distances[id]+1 && augment(partner)) {
+				if previous, ok := pairs[id]; ok {
+					delete(pairs, previous)
+				}
+				matchedEdges[id] = next.Edge
+				pairs[id] = next.To
+				pairs[next.To] = id
+				return true
+			}
+		}
+		distances[id] = math.MaxInt
+		return false
+	}
+
+	for buildLayers() {
+		for _, id := range bipartition.Left {
+			if _, matched := pairs[id]; !matched {
+				augment(id)
+			}
+		}
+	}
+
+	matching := Matching{Edges: make([]Edge, 0, len(matchedEdges)), Pairs: pairs}
+	for _, edge := range matchedEdges {
+		matching.Edges = append(matching.Edges, edge)
+	}
+	sort.Slice(matching.Edges, func(i, j int) bool {
+		return lessEdgeID(matching.Edges[i].GetID(), matching.Edges[j].GetID())
+	})
+	return matching, nil
+}
@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildBipartiteGraph(t *testing.T) Graph {
+	gb := NewGraphBuilder()
+	for i := 1; i < 8; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 4)
+	gb.AddEdge(1, 5)
+	gb.AddEdge(2, 4)
+	gb.AddEdge(3, 5)
+	gb.AddEdge(3, 6)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func Test_IsBipartite(t *testing.T) {
+	graph := buildBipartiteGraph(t)
+	actual_bipartition, err := IsBipartite(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, Bipartition{Left: []NodeID{1, 2, 3, 7}, Right: []NodeID{4, 5, 6}}, actual_bipartition)
+}
+
+func Test_IsBipartite_OddCycle(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 6; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	// the path 1 - 2 - 3 leads to the triangle 3, 4, 5
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 4)
+	gb.AddEdge(4, 5)
+	gb.AddEdge(5, 3)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = IsBipartite(graph)
+	var oddCycleError OddCycleError
+	assert.True(t, errors.As(err, &oddCycleError))
+	assert.Equal(t, []NodeID{3, 5, 4}, oddCycleError.NodeIDs)
+
+	_, err = MaximumBipartiteMatching(graph)
+	assert.ErrorAs(t, err, &oddCycleError)
+}
+
+func Test_IsBipartite_SelfLoop(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowRedundantEdges: true})
+	gb.AddNode(1)
+	gb.AddEdge(1, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = IsBipartite(graph)
+	var oddCycleError OddCycleError
+	assert.True(t, errors.As(err, &oddCycleError))
+	assert.Equal(t, []NodeID{1}, oddCycleError.NodeIDs)
+}
+
+func Test_IsBipartite_Directed(t *testing.T) {
+	graph := buildWeightedGraph(t, BuilderOptions{IsDirected: true})
+	_, err := IsBipartite(graph)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "IsBipartite"})
+	_, err = MaximumBipartiteMatching(graph)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "MaximumBipartiteMatching"})
+}
+
+func Test_MaximumBipartiteMatching(t *testing.T) {
+	graph := buildBipartiteGraph(t)
+	actual_matching, err := MaximumBipartiteMatching(graph)
+	assert.NoError(t, err)
+	actual_edges := make([]EdgeID, 0)
+	for _, edge := range actual_matching.Edges {
+		actual_edges = append(actual_edges, edge.GetID())
+	}
+	assert.Equal(t, []EdgeID{{From: 1, To: 5}, {From: 2, To: 4}, {From: 3, To: 6}}, actual_edges)
+	assert.Equal(t, map[NodeID]NodeID{1: 5, 5: 1, 2: 4, 4: 2, 3: 6, 6: 3}, actual_matching.Pairs)
+}
+
+func Test_MaximumBipartiteMatching_Grid(t *testing.T) {
+	// a 4x5 grid is bipartite and has a perfect matching
+	gb := NewGraphBuilder()
+	for i := 0; i < 20; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 5; y++ {
+			if x < 3 {
+				gb.AddEdge(NodeID(5*x+y), NodeID(5*(x+1)+y))
+			}
+			if y < 4 {
+				gb.AddEdge(NodeID(5*x+y), NodeID(5*x+y+1))
+			}
+		}
+	}
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_matching, err := MaximumBipartiteMatching(graph)
+	assert.NoError(t, err)
+	assert.Len(t, actual_matching.Edges, 10)
+	assert.Len(t, actual_matching.Pairs, 20)
+}
@@ -0,0 +1,59 @@
+package graph
+
+import "sync"
+
+// Freeze copies g into a new graph that can no longer change, so it is safe for concurrent use.
+// Use it to hand a MutableGraph, or a view of one, to other goroutines
+// while the original keeps being edited.
+func Freeze[N, E any](g TypedGraph[N, E]) (TypedGraph[N, E], error) {
+	builder := transformBuilder[N, E](g.IsDirected(), storageOf(g))
+	if err := copyGraph[N, E](g, builder); err != nil {
+		return nil, err
+	}
+	return builder.Build()
+}
+
+// concurrentGraphBuilder serializes every call to the builder it wraps.
+type concurrentGraphBuilder[N, E any] struct {
+	mutex   sync.Mutex
+	builder TypedGraphBuilder[N, E]
+}
+
+// NewConcurrentGraphBuilder creates a builder for a graph with untyped node and edge values
+// that can be called from several goroutines at once.
+func NewConcurrentGraphBuilder(bo ...BuilderOptions) GraphBuilder {
+	return NewTypedConcurrentGraphBuilder[interface{}, interface{}](bo...)
+}
+
+// NewTypedConcurrentGraphBuilder creates a builder like NewTypedGraphBuilder
+// that can be called from several goroutines at once.
+// Calls are applied one at a time, so the call indices reported with CollectAllErrors
+// follow the order the calls acquired the builder rather than the order they were made in.
+func NewTypedConcurrentGraphBuilder[N, E any](bo ...BuilderOptions) TypedGraphBuilder[N, E] {
+	return &concurrentGraphBuilder[N, E]{builder: NewTypedGraphBuilder[N, E](bo...)}
+}
+
+func (cb *concurrentGraphBuilder[N, E]) AddNode(id NodeID, value ...N) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.builder.AddNode(id, value...)
+}
+
+func (cb *concurrentGraphBuilder[N, E]) AddEdge(from NodeID, to NodeID, value ...E) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.builder.AddEdge(from, to, value...)
+}
+
+func (cb *concurrentGraphBuilder[N, E]) Build() (TypedGraph[N, E], error) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.builder.Build()
+}
+
+// BuildMutable is like Build. The resulting graph is not safe for concurrent use.
+func (cb *concurrentGraphBuilder[N, E]) BuildMutable() (TypedMutableGraph[N, E], error) {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.builder.BuildMutable()
+}
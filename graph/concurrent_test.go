@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Freeze(t *testing.T) {
+	mutable, err := Edit(buildDepthFirstGraph(t, BuilderOptions{IsDirected: true}))
+	assert.NoError(t, err)
+	frozen, err := Freeze[interface{}, interface{}](mutable)
+	assert.NoError(t, err)
+
+	// readers search the frozen graph while the mutable graph keeps changing
+	var board atomic.Value
+	board.Store(frozen)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				graph := board.Load().(Graph)
+				result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{})
+				assert.NoError(t, err)
+				assert.GreaterOrEqual(t, len(result.Depths), 5)
+			}
+		}()
+	}
+	for i := 6; i < 26; i++ {
+		assert.NoError(t, mutable.AddNode(NodeID(i)))
+		assert.NoError(t, mutable.AddEdge(NodeID(i-1), NodeID(i)))
+		rebuilt, err := Freeze[interface{}, interface{}](mutable)
+		assert.NoError(t, err)
+		board.Store(rebuilt)
+	}
+	wg.Wait()
+
+	nodes, err := frozen.GetNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3, 4, 5}, nodeIDs(nodes))
+	nodes, err = board.Load().(Graph).GetNodes()
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 25)
+}
+
+func Test_ConcurrentGraphBuilder(t *testing.T) {
+	gb := NewConcurrentGraphBuilder(BuilderOptions{IsDirected: true, BuildEdgesIncrementally: true})
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(id NodeID) {
+			defer wg.Done()
+			gb.AddNode(id, int(id))
+		}(NodeID(i))
+	}
+	wg.Wait()
+	for i := 1; i < 50; i++ {
+		wg.Add(1)
+		go func(id NodeID) {
+			defer wg.Done()
+			gb.AddEdge(id-1, id)
+		}(NodeID(i))
+	}
+	wg.Wait()
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	nodes, err := graph.GetNodes()
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 50)
+	edges, err := graph.GetEdges()
+	assert.NoError(t, err)
+	assert.Len(t, edges, 49)
+
+	// the errors are collected from every goroutine
+	typed := NewTypedConcurrentGraphBuilder[int, int](BuilderOptions{CollectAllErrors: true})
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			typed.AddNode(1)
+		}()
+	}
+	wg.Wait()
+	_, err = typed.BuildMutable()
+	var builderError BuilderError
+	assert.ErrorAs(t, err, &builderError)
+	assert.Len(t, builderError.Calls, 9)
+}
@@ -0,0 +1,74 @@
+package graph
+
+// CondensedGraph is a directed acyclic graph whose nodes are the strongly connected components of another graph.
+// The id of a component is its index in the list returned by Tarjan and its value is the sorted ids of its members.
+// There is an edge between two components if the original graph has at least one edge between their members;
+// its value is the ids of those edges.
+type CondensedGraph struct {
+	Graph
+	components map[NodeID]NodeID
+}
+
+// ComponentOf returns the id of the component containing the node with the given id.
+// If the node is not in the original graph then this returns a "node not found" error.
+func (cg CondensedGraph) ComponentOf(id NodeID) (NodeID, error) {
+	component, ok := cg.components[id]
+	if !ok {
+		return 0, NodeNotFoundError{NodeID: id}
+	}
+	return component, nil
+}
+
+// Condensation contracts every strongly connected component of g into a single node.
+// Components of an undirected graph are its connected components, so its condensation has no edges.
+func Condensation(g Graph) (CondensedGraph, error) {
+	sccs, err := Tarjan(g)
+	if err != nil {
+		return CondensedGraph{}, err
+	}
+	components := make(map[NodeID]NodeID)
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i, scc := range sccs {
+		members := make([]NodeID, 0)
+		for _, node := range scc {
+			members = append(members, node.GetID())
+			components[node.GetID()] = NodeID(i)
+		}
+		gb.AddNode(NodeID(i), members)
+	}
+
+	edges, err := g.GetEdges()
+	if err != nil {
+		return CondensedGraph{}, err
+	}
+	between := make(map[NodeID]map[NodeID][]EdgeID)
+	for _, edge := range edges {
+		id := edge.GetID()
+		from, ok := components[id.From]
+		if !ok {
+			return CondensedGraph{}, NodeNotFoundError{NodeID: id.From}
+		}
+		to, ok := components[id.To]
+		if !ok {
+			return CondensedGraph{}, NodeNotFoundError{NodeID: id.To}
+		}
+		if from == to {
+			continue
+		}
+		if between[from] == nil {
+			between[from] = make(map[NodeID][]EdgeID)
+		}
+		between[from][to] = append(between[from][to], id)
+	}
+	for from, tos := range between {
+		for to, ids := range tos {
+			gb.AddEdge(from, to, ids)
+		}
+	}
+
+	condensed, err := gb.Build()
+	if err != nil {
+		return CondensedGraph{}, err
+	}
+	return CondensedGraph{Graph: condensed, components: components}, nil
+}
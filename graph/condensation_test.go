@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Condensation(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 7; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	// cycle of 1, 2, 3 with two edges into the cycle of 4, 5
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 1)
+	gb.AddEdge(1, 4)
+	gb.AddEdge(3, 5)
+	gb.AddEdge(4, 5)
+	gb.AddEdge(5, 4)
+	// single node 6 reachable from 5
+	gb.AddEdge(5, 6)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	condensed, err := Condensation(graph)
+	assert.NoError(t, err)
+	assert.True(t, condensed.IsDirected())
+	expected_components := map[NodeID]NodeID{1: 2, 2: 2, 3: 2, 4: 1, 5: 1, 6: 0}
+	for id, expected_component := range expected_components {
+		actual_component, err := condensed.ComponentOf(id)
+		assert.NoError(t, err)
+		assert.Equal(t, expected_component, actual_component)
+	}
+	_, err = condensed.ComponentOf(7)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 7})
+
+	node, err := condensed.GetNode(2)
+	assert.NoError(t, err)
+	value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3}, value)
+
+	edges, err := condensed.GetEdges()
+	assert.NoError(t, err)
+	actual_edges := make([]EdgeID, 0)
+	for _, edge := range edges {
+		actual_edges = append(actual_edges, edge.GetID())
+	}
+	assert.Equal(t, []EdgeID{{From: 1, To: 0}, {From: 2, To: 1}}, actual_edges)
+
+	edge, err := condensed.GetEdge(2, 1)
+	assert.NoError(t, err)
+	value, err = edge.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 1, To: 4}, {From: 3, To: 5}}, value)
+}
+
+func Test_Condensation_Undirected(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 4; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	condensed, err := Condensation(graph)
+	assert.NoError(t, err)
+	nodes, err := condensed.GetNodes()
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 2)
+	edges, err := condensed.GetEdges()
+	assert.NoError(t, err)
+	assert.Empty(t, edges)
+	component, err := condensed.ComponentOf(2)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeID(1), component)
+}
@@ -0,0 +1,56 @@
+package graph
+
+// ConnectedComponent is a set of nodes that are all connected to each other and to no other node.
+type ConnectedComponent []Node
+
+// ConnectedComponents finds the connected components of an undirected graph.
+// The components are sorted the same way as the ones returned by Kosaraju.
+func ConnectedComponents(g Graph) ([]ConnectedComponent, error) {
+	if g.IsDirected() {
+		return nil, CannotUseForDirectedGraphError{MethodName: "ConnectedComponents"}
+	}
+	return findConnectedComponents(g)
+}
+
+// WeaklyConnectedComponents finds the components of a directed graph
+// that are connected when the direction of the edges is ignored.
+// The components are sorted the same way as the ones returned by Kosaraju.
+func WeaklyConnectedComponents(g Graph) ([]ConnectedComponent, error) {
+	if !g.IsDirected() {
+		return nil, CannotUseForUndirectedGraphError{MethodName: "WeaklyConnectedComponents"}
+	}
+	return findConnectedComponents(g)
+}
+
+func findConnectedComponents(g Graph) ([]ConnectedComponent, error) {
+	allNodes, err := g.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return nil, err
+	}
+	ds := NewDisjointSet()
+	for _, node := range allNodes {
+		ds.Add(node.GetID())
+	}
+	for _, edge := range edges {
+		ds.Union(edge.GetID().From, edge.GetID().To)
+	}
+
+	componentIndices := make(map[NodeID]int)
+	components := make([]ConnectedComponent, 0, ds.Count())
+	for _, node := range allNodes {
+		root := ds.Find(node.GetID())
+		index, ok := componentIndices[root]
+		if !ok {
+			index = len(components)
+			componentIndices[root] = index
+			components = append(components, make(ConnectedComponent, 0, ds.Size(root)))
+		}
+		components[index] = append(components[index], node)
+	}
+	sortComponents(components)
+	return components, nil
+}
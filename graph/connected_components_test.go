@@ -0,0 +1,54 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// componentIDs returns the ids of the nodes of every component.
+func componentIDs[C ~[]Node](components []C) [][]NodeID {
+	ids := make([][]NodeID, 0)
+	for _, component := range components {
+		ids = append(ids, nodeIDs(component))
+	}
+	return ids
+}
+
+func Test_ConnectedComponents(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 8; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 5)
+	gb.AddEdge(5, 3)
+	gb.AddEdge(2, 4)
+	gb.AddEdge(6, 7)
+	gb.AddEdge(4, 7)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_components, err := ConnectedComponents(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]NodeID{{1, 3, 5}, {2, 4, 6, 7}}, componentIDs(actual_components))
+
+	_, err = WeaklyConnectedComponents(graph)
+	assert.ErrorIs(t, err, CannotUseForUndirectedGraphError{MethodName: "WeaklyConnectedComponents"})
+}
+
+func Test_WeaklyConnectedComponents(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 7; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(3, 2)
+	gb.AddEdge(4, 5)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_components, err := WeaklyConnectedComponents(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]NodeID{{6}, {4, 5}, {1, 2, 3}}, componentIDs(actual_components))
+
+	_, err = ConnectedComponents(graph)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "ConnectedComponents"})
+}
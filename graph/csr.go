@@ -0,0 +1,303 @@
+package graph
+
+import "sort"
+
+// StorageBackend selects how Build stores a graph.
+type StorageBackend int
+
+const (
+	// MapStorage keeps nodes and edges in maps keyed by NodeID. It is the default.
+	MapStorage StorageBackend = iota
+	// CSRStorage keeps nodes and edges in sorted arrays in compressed sparse row form.
+	// It uses less memory, and GetNodes, GetEdges and the edges of a node are read without sorting,
+	// while finding a node or an edge takes a binary search instead of a map lookup.
+	CSRStorage
+)
+
+// csrGraph stores the nodes sorted by id and the edges sorted by EdgeID.
+// The edges of the node at position i are rows[rowOffsets[i]:rowOffsets[i+1]],
+// which hold positions in edgeIDs sorted by the other endpoint and then by index.
+// In a directed graph rows holds the outgoing edges and columns the incoming edges.
+type csrGraph[N, E any] struct {
+	directed      bool
+	nodeIDs       []NodeID
+	nodeValues    []typedValue[N]
+	edgeIDs       []EdgeID
+	edgeValues    []typedValue[E]
+	rowOffsets    []int
+	rows          []int
+	columnOffsets []int
+	columns       []int
+	// nodes and edges cache the sorted results of GetNodes and GetEdges.
+	nodes []TypedNode[N, E]
+	edges []TypedEdge[N, E]
+}
+
+func (builder *graphBuilder[N, E]) buildCSRGraph() (*csrGraph[N, E], error) {
+	graph := &csrGraph[N, E]{directed: builder.builderOptions.IsDirected}
+	for id := range builder.nodes {
+		graph.nodeIDs = append(graph.nodeIDs, id)
+	}
+	sortNodeIDs(graph.nodeIDs)
+	positions := make(map[NodeID]int, len(graph.nodeIDs))
+	graph.nodeValues = make([]typedValue[N], len(graph.nodeIDs))
+	for i, id := range graph.nodeIDs {
+		positions[id] = i
+		graph.nodeValues[i] = builder.nodes[id]
+	}
+
+	values := make(map[EdgeID]typedValue[E])
+	for from, toVals := range builder.edges {
+		for to, vals := range toVals {
+			if _, exists := positions[from]; !exists {
+				return nil, NodeNotFoundError{NodeID: from}
+			}
+			if _, exists := positions[to]; !exists {
+				return nil, NodeNotFoundError{NodeID: to}
+			}
+			for _, val := range vals {
+				id := EdgeID{From: from, To: to, Index: val.index}
+				graph.edgeIDs = append(graph.edgeIDs, id)
+				values[id] = val.value
+			}
+		}
+	}
+	sort.Slice(graph.edgeIDs, func(i, j int) bool {
+		return lessEdgeID(graph.edgeIDs[i], graph.edgeIDs[j])
+	})
+	graph.edgeValues = make([]typedValue[E], len(graph.edgeIDs))
+	for i, id := range graph.edgeIDs {
+		graph.edgeValues[i] = values[id]
+	}
+
+	// visiting the edges in sorted order fills every row sorted by the other endpoint
+	rowEnds := make([]int, 0, len(graph.edgeIDs))
+	columnEnds := make([]int, 0, len(graph.edgeIDs))
+	for _, id := range graph.edgeIDs {
+		rowEnds = append(rowEnds, positions[id.From])
+		if graph.directed {
+			columnEnds = append(columnEnds, positions[id.To])
+		} else if id.From != id.To {
+			rowEnds = append(rowEnds, positions[id.To])
+		} else {
+			// a self-loop is only in the row of its node once
+			rowEnds = append(rowEnds, -1)
+		}
+	}
+	if graph.directed {
+		graph.rowOffsets, graph.rows = compressRows(len(graph.nodeIDs), rowEnds, 1)
+		graph.columnOffsets, graph.columns = compressRows(len(graph.nodeIDs), columnEnds, 1)
+	} else {
+		graph.rowOffsets, graph.rows = compressRows(len(graph.nodeIDs), rowEnds, 2)
+	}
+
+	graph.nodes = make([]TypedNode[N, E], len(graph.nodeIDs))
+	for i := range graph.nodeIDs {
+		graph.nodes[i] = csrNode[N, E]{graph: graph, position: i}
+	}
+	graph.edges = make([]TypedEdge[N, E], len(graph.edgeIDs))
+	for i := range graph.edgeIDs {
+		graph.edges[i] = csrEdge[N, E]{graph: graph, position: i}
+	}
+	return graph, nil
+}
+
+// compressRows groups edge positions by node. ends holds the node positions of perEdge endpoints
+// for every edge in order, where -1 is skipped. It returns the offsets of every node's row and the rows themselves.
+func compressRows(nodeCount int, ends []int, perEdge int) ([]int, []int) {
+	offsets := make([]int, nodeCount+1)
+	for _, end := range ends {
+		if end >= 0 {
+			offsets[end+1]++
+		}
+	}
+	for i := 1; i < len(offsets); i++ {
+		offsets[i] += offsets[i-1]
+	}
+	rows := make([]int, offsets[nodeCount])
+	next := append([]int{}, offsets[:nodeCount]...)
+	for i, end := range ends {
+		if end < 0 {
+			continue
+		}
+		rows[next[end]] = i / perEdge
+		next[end]++
+	}
+	return offsets, rows
+}
+
+func (cg *csrGraph[N, E]) position(id NodeID) (int, bool) {
+	i := sort.Search(len(cg.nodeIDs), func(i int) bool {
+		return cg.nodeIDs[i] >= id
+	})
+	return i, i < len(cg.nodeIDs) && cg.nodeIDs[i] == id
+}
+
+// otherEnd returns the endpoint of the edge at position e that is not the node with the given id.
+func (cg *csrGraph[N, E]) otherEnd(e int, id NodeID) NodeID {
+	if cg.edgeIDs[e].From == id {
+		return cg.edgeIDs[e].To
+	}
+	return cg.edgeIDs[e].From
+}
+
+func (cg *csrGraph[N, E]) GetNode(id NodeID) (TypedNode[N, E], error) {
+	position, exists := cg.position(id)
+	if !exists {
+		return nil, NodeNotFoundError{NodeID: id}
+	}
+	return cg.nodes[position], nil
+}
+
+func (cg *csrGraph[N, E]) GetEdge(from NodeID, to NodeID) (TypedEdge[N, E], error) {
+	edges, err := cg.GetEdgesBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return edges[0], nil
+}
+
+func (cg *csrGraph[N, E]) GetEdgesBetween(from NodeID, to NodeID) ([]TypedEdge[N, E], error) {
+	position, exists := cg.position(from)
+	if !exists {
+		return nil, EdgeNotFoundError{FromID: from, ToID: to}
+	}
+	row := cg.rows[cg.rowOffsets[position]:cg.rowOffsets[position+1]]
+	start := sort.Search(len(row), func(i int) bool {
+		return cg.otherEnd(row[i], from) >= to
+	})
+	edges := make([]TypedEdge[N, E], 0)
+	for i := start; i < len(row) && cg.otherEnd(row[i], from) == to; i++ {
+		edges = append(edges, cg.edges[row[i]])
+	}
+	if len(edges) == 0 {
+		return nil, EdgeNotFoundError{FromID: from, ToID: to}
+	}
+	return edges, nil
+}
+
+func (cg *csrGraph[N, E]) GetNodes() ([]TypedNode[N, E], error) {
+	return append([]TypedNode[N, E]{}, cg.nodes...), nil
+}
+
+func (cg *csrGraph[N, E]) GetEdges() ([]TypedEdge[N, E], error) {
+	return append([]TypedEdge[N, E]{}, cg.edges...), nil
+}
+
+func (cg *csrGraph[N, E]) IsDirected() bool {
+	return cg.directed
+}
+
+// removeRefs returns the graph as is, since tests compare CSR graphs through the Graph interface.
+func (cg *csrGraph[N, E]) removeRefs() TypedGraph[N, E] {
+	return cg
+}
+
+type csrNode[N, E any] struct {
+	graph    *csrGraph[N, E]
+	position int
+}
+
+func (cn csrNode[N, E]) edgesOf(offsets []int, positions []int) []TypedEdge[N, E] {
+	edges := make([]TypedEdge[N, E], 0, offsets[cn.position+1]-offsets[cn.position])
+	for _, e := range positions[offsets[cn.position]:offsets[cn.position+1]] {
+		edges = append(edges, cn.graph.edges[e])
+	}
+	return edges
+}
+
+func (cn csrNode[N, E]) GetID() NodeID {
+	return cn.graph.nodeIDs[cn.position]
+}
+
+func (cn csrNode[N, E]) GetIncomingEdges() ([]TypedEdge[N, E], error) {
+	if !cn.graph.directed {
+		return nil, CannotUseForUndirectedGraphError{"Node.GetIncomingEdges"}
+	}
+	return cn.edgesOf(cn.graph.columnOffsets, cn.graph.columns), nil
+}
+
+func (cn csrNode[N, E]) GetOutgoingEdges() ([]TypedEdge[N, E], error) {
+	if !cn.graph.directed {
+		return nil, CannotUseForUndirectedGraphError{"Node.GetOutgoingEdges"}
+	}
+	return cn.edgesOf(cn.graph.rowOffsets, cn.graph.rows), nil
+}
+
+func (cn csrNode[N, E]) GetIncidentEdges() ([]TypedEdge[N, E], error) {
+	if !cn.graph.directed {
+		return cn.edgesOf(cn.graph.rowOffsets, cn.graph.rows), nil
+	}
+	incoming := cn.edgesOf(cn.graph.columnOffsets, cn.graph.columns)
+	return append(incoming, cn.edgesOf(cn.graph.rowOffsets, cn.graph.rows)...), nil
+}
+
+func (cn csrNode[N, E]) GetValue() (N, error) {
+	value := cn.graph.nodeValues[cn.position]
+	if !value.HasValue {
+		var zero N
+		return zero, NoValueFoundInNodeError{cn.GetID()}
+	}
+	return value.RawValue, nil
+}
+
+// removeRef returns the node as is, like csrGraph.removeRefs.
+func (cn csrNode[N, E]) removeRef() TypedNode[N, E] {
+	return cn
+}
+
+type csrEdge[N, E any] struct {
+	graph    *csrGraph[N, E]
+	position int
+}
+
+func (ce csrEdge[N, E]) GetID() EdgeID {
+	return ce.graph.edgeIDs[ce.position]
+}
+
+func (ce csrEdge[N, E]) GetTo() (TypedNode[N, E], error) {
+	if !ce.graph.directed {
+		return nil, CannotUseForUndirectedGraphError{"Edge.GetTo"}
+	}
+	return ce.graph.GetNode(ce.GetID().To)
+}
+
+func (ce csrEdge[N, E]) GetFrom() (TypedNode[N, E], error) {
+	if !ce.graph.directed {
+		return nil, CannotUseForUndirectedGraphError{"Edge.GetFrom"}
+	}
+	return ce.graph.GetNode(ce.GetID().From)
+}
+
+func (ce csrEdge[N, E]) GetNodes() ([]TypedNode[N, E], error) {
+	id := ce.GetID()
+	nodes := make([]TypedNode[N, E], 0)
+	from, err := ce.graph.GetNode(id.From)
+	if err != nil {
+		return nodes, err
+	}
+	nodes = append(nodes, from)
+	if id.From == id.To {
+		return nodes, nil
+	}
+	to, err := ce.graph.GetNode(id.To)
+	if err != nil {
+		return nodes, err
+	}
+	return append(nodes, to), nil
+}
+
+func (ce csrEdge[N, E]) GetValue() (E, error) {
+	value := ce.graph.edgeValues[ce.position]
+	if !value.HasValue {
+		var zero E
+		id := ce.GetID()
+		return zero, NoValueFoundInEdgeError{FromID: id.From, ToID: id.To}
+	}
+	return value.RawValue, nil
+}
+
+// removeRef returns the edge as is, like csrGraph.removeRefs.
+func (ce csrEdge[N, E]) removeRef() TypedEdge[N, E] {
+	return ce
+}
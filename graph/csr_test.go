@@ -0,0 +1,214 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertSameBehavior checks that every method of actual returns what the same method of expected returns.
+func assertSameBehavior(t *testing.T, expected, actual Graph) {
+	assert.Equal(t, expected.IsDirected(), actual.IsDirected())
+	expected_nodes, err := expected.GetNodes()
+	assert.NoError(t, err)
+	actual_nodes, err := actual.GetNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, nodeIDs(expected_nodes), nodeIDs(actual_nodes))
+	assert.Equal(t, allEdgeIDs(t, expected), allEdgeIDs(t, actual))
+	expected_edges, err := edgeValues(expected)
+	assert.NoError(t, err)
+	actual_edges, err := edgeValues(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, expected_edges, actual_edges)
+
+	ids := append(nodeIDs(expected_nodes), 100)
+	for i, node := range actual_nodes {
+		expected_node := expected_nodes[i]
+		for _, get := range []func(Node) ([]Edge, error){Node.GetIncomingEdges, Node.GetOutgoingEdges, Node.GetIncidentEdges} {
+			expected_edges, expected_err := get(expected_node)
+			actual_edges, actual_err := get(node)
+			assert.Equal(t, expected_err, actual_err)
+			assert.Equal(t, edgeIDs(expected_edges), edgeIDs(actual_edges), "edges of node %d", node.GetID())
+		}
+		expected_value, expected_err := expected_node.GetValue()
+		actual_value, actual_err := node.GetValue()
+		assert.Equal(t, expected_value, actual_value)
+		assert.Equal(t, expected_err, actual_err)
+	}
+	for _, from := range ids {
+		_, expected_err := expected.GetNode(from)
+		_, actual_err := actual.GetNode(from)
+		assert.Equal(t, expected_err, actual_err)
+		for _, to := range ids {
+			expected_between, expected_err := expected.GetEdgesBetween(from, to)
+			actual_between, actual_err := actual.GetEdgesBetween(from, to)
+			assert.Equal(t, expected_err, actual_err)
+			assert.Equal(t, edgeIDs(expected_between), edgeIDs(actual_between), "edges between %d and %d", from, to)
+			expected_edge, expected_err := expected.GetEdge(from, to)
+			actual_edge, actual_err := actual.GetEdge(from, to)
+			assert.Equal(t, expected_err, actual_err)
+			if expected_err != nil {
+				continue
+			}
+			assert.Equal(t, expected_edge.GetID(), actual_edge.GetID())
+			expected_endpoints, err := expected_edge.GetNodes()
+			assert.NoError(t, err)
+			actual_endpoints, err := actual_edge.GetNodes()
+			assert.NoError(t, err)
+			assert.Equal(t, nodeIDs(expected_endpoints), nodeIDs(actual_endpoints))
+			_, expected_err = expected_edge.GetFrom()
+			_, actual_err = actual_edge.GetFrom()
+			assert.Equal(t, expected_err, actual_err)
+		}
+	}
+}
+
+func Test_CSRStorage(t *testing.T) {
+	build := func(gb GraphBuilder) {
+		for _, id := range []NodeID{-3, 0, 2, 5, 7} {
+			gb.AddNode(id, int(id)*10)
+		}
+		gb.AddNode(9)
+		gb.AddEdge(0, 2, "a")
+		gb.AddEdge(2, 0, "b")
+		gb.AddEdge(0, 2, "c")
+		gb.AddEdge(5, -3)
+		gb.AddEdge(5, 5, "loop")
+		gb.AddEdge(7, 0, "d")
+		gb.AddEdge(2, 7)
+	}
+	for _, bo := range []BuilderOptions{
+		{AllowParallelEdges: true, AllowRedundantEdges: true},
+		{AllowParallelEdges: true, AllowRedundantEdges: true, IsDirected: true},
+	} {
+		gb := NewGraphBuilder(bo)
+		build(gb)
+		mapGraph, err := gb.Build()
+		assert.NoError(t, err)
+		bo.Storage = CSRStorage
+		gb = NewGraphBuilder(bo)
+		build(gb)
+		csrGraph, err := gb.Build()
+		assert.NoError(t, err)
+		assertSameBehavior(t, mapGraph, csrGraph)
+		expected_sccs, err := Tarjan(mapGraph)
+		assert.NoError(t, err)
+		actual_sccs, err := Tarjan(csrGraph)
+		assert.NoError(t, err)
+		assert.Equal(t, componentIDs(expected_sccs), componentIDs(actual_sccs))
+		AssertGraphEquals(t, mapGraph, csrGraph)
+	}
+}
+
+func Test_CSRStorage_Errors(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{Storage: CSRStorage})
+	gb.AddNode(1)
+	gb.AddEdge(1, 2)
+	_, err := gb.Build()
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 2})
+
+	// mutable graphs always use the map backend
+	gb = NewGraphBuilder(BuilderOptions{Storage: CSRStorage})
+	gb.AddNode(1)
+	mutable, err := gb.BuildMutable()
+	assert.NoError(t, err)
+	assert.NoError(t, mutable.AddNode(2))
+
+	// and editing a CSR graph copies it into one
+	gb = NewGraphBuilder(BuilderOptions{Storage: CSRStorage})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	mutable, err = Edit(graph)
+	assert.NoError(t, err)
+	assert.NoError(t, mutable.RemoveEdge(2, 1))
+	_, err = graph.GetEdge(1, 2)
+	assert.NoError(t, err)
+}
+
+func Test_CSRStorage_Grid(t *testing.T) {
+	mapGraph := buildGridGraph(t, BuilderOptions{}, 8, 6)
+	csrGraph := buildGridGraph(t, BuilderOptions{Storage: CSRStorage}, 8, 6)
+	assertSameBehavior(t, mapGraph, csrGraph)
+	expected_tree, err := ShortestPaths(mapGraph, 0, valueWeight)
+	assert.NoError(t, err)
+	actual_tree, err := ShortestPaths(csrGraph, 0, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, expected_tree.Distances, actual_tree.Distances)
+}
+
+var storageBackends = []struct {
+	name    string
+	storage StorageBackend
+}{
+	{"Map", MapStorage},
+	{"CSR", CSRStorage},
+}
+
+func Benchmark_Build(b *testing.B) {
+	for _, backend := range storageBackends {
+		b.Run(backend.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				buildGridGraph(b, BuilderOptions{IsDirected: true, Storage: backend.storage}, 300, 300)
+			}
+		})
+	}
+}
+
+func Benchmark_GetNodes(b *testing.B) {
+	for _, backend := range storageBackends {
+		graph := buildGridGraph(b, BuilderOptions{IsDirected: true, Storage: backend.storage}, 300, 300)
+		b.Run(backend.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				graph.GetNodes()
+			}
+		})
+	}
+}
+
+func Benchmark_GetEdges(b *testing.B) {
+	for _, backend := range storageBackends {
+		graph := buildGridGraph(b, BuilderOptions{IsDirected: true, Storage: backend.storage}, 300, 300)
+		b.Run(backend.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				graph.GetEdges()
+			}
+		})
+	}
+}
+
+func Benchmark_GetEdge(b *testing.B) {
+	for _, backend := range storageBackends {
+		graph := buildGridGraph(b, BuilderOptions{IsDirected: true, Storage: backend.storage}, 300, 300)
+		b.Run(backend.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				id := NodeID(i % 89000)
+				graph.GetEdge(id, id+1)
+			}
+		})
+	}
+}
+
+func Benchmark_BreadthFirstSearch(b *testing.B) {
+	for _, backend := range storageBackends {
+		graph := buildGridGraph(b, BuilderOptions{IsDirected: true, Storage: backend.storage}, 300, 300)
+		b.Run(backend.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				BreadthFirstSearch(graph, BreadthFirstSearchOptions{})
+			}
+		})
+	}
+}
+
+func Benchmark_ShortestPaths(b *testing.B) {
+	for _, backend := range storageBackends {
+		graph := buildGridGraph(b, BuilderOptions{IsDirected: true, Storage: backend.storage}, 300, 300)
+		b.Run(backend.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ShortestPaths(graph, 0, valueWeight)
+			}
+		})
+	}
+}
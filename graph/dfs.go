@@ -1,72 +1,225 @@
 package graph
 
-type NodeFunction func(Node)
+import "context"
+
+// TraversalControl tells a search what to do after one of its hooks returns.
+type TraversalControl int
+
+const (
+	// Continue carries on with the search as usual.
+	Continue TraversalControl = iota
+	// SkipChildren does not follow the edges of the node the hook was called with.
+	SkipChildren
+	// Stop ends the whole search without an error.
+	Stop
+)
+
+// NodeFunction is a search hook called with a node.
+// If it returns an error, the search stops and returns that error.
+type NodeFunction func(Node) (TraversalControl, error)
+
+// EdgeFunction is a search hook called with an edge.
+// If it returns an error, the search stops and returns that error.
+type EdgeFunction func(Edge) (TraversalControl, error)
 
 type DepthFirstSearchOptions struct {
-	ReverseGraph    bool
-	CustomOrder     []Node
+	ReverseGraph bool
+	CustomOrder  []Node
+	// BeforeRecursion is called when a node is first reached.
+	// Returning SkipChildren leaves the node's neighbors unexplored.
 	BeforeRecursion NodeFunction
-	AfterRecursion  NodeFunction
-	BeforeSearch    NodeFunction
-	AfterSearch     NodeFunction
-	IsUndirected    bool
+	// AfterRecursion is called once all of a node's neighbors have been explored.
+	AfterRecursion NodeFunction
+	// BeforeSearch is called with every node a search may start from.
+	// Returning SkipChildren skips the search from that node.
+	BeforeSearch NodeFunction
+	AfterSearch  NodeFunction
+	IsUndirected bool
+	// TreeEdge is called with every edge that leads to a node reached for the first time.
+	// Returning SkipChildren does not follow the edge.
+	TreeEdge EdgeFunction
+	// BackEdge is called with every edge that leads back to a node still being explored,
+	// including self loops. In an undirected search the tree edge to a node's parent is not a back edge.
+	BackEdge EdgeFunction
+	// ForwardEdge is called with every edge that leads to an already explored descendant.
+	// It is only called in directed searches.
+	ForwardEdge EdgeFunction
+	// CrossEdge is called with every other edge leading to an already explored node.
+	// It is only called in directed searches.
+	CrossEdge EdgeFunction
+}
+
+// DepthFirstSearchResult holds the timestamps and the search forest of a depth first search.
+// Timestamps come from one counter starting at 1 that is incremented whenever a node is discovered or finished.
+type DepthFirstSearchResult struct {
+	// Discovery maps every reached node to the time it was first reached.
+	Discovery map[NodeID]int
+	// Finish maps every explored node to the time all of its neighbors had been explored.
+	Finish map[NodeID]int
+	// Parents maps every reached node except the roots of the search to the tree edge it was reached through.
+	Parents map[NodeID]Edge
+}
+
+func callNodeFunction(hook NodeFunction, node Node) (TraversalControl, error) {
+	if hook == nil {
+		return Continue, nil
+	}
+	return hook(node)
 }
 
-func depthFirstSearchHelper(node Node, visited map[NodeID]bool, options DepthFirstSearchOptions) {
-	if !visited[node.GetID()] {
-		visited[node.GetID()] = true
-		edges, _ := node.GetOutgoingEdges()
-		if options.ReverseGraph {
-			edges, _ = node.GetIncomingEdges()
+func callEdgeFunction(hook EdgeFunction, edge Edge) (TraversalControl, error) {
+	if hook == nil {
+		return Continue, nil
+	}
+	return hook(edge)
+}
+
+// depthFirstFrame is a node on the search stack along with the edges left to explore.
+type depthFirstFrame struct {
+	node     Node
+	adjacent []adjacentEdge
+	next     int
+}
+
+// classifyEdge returns the hook for a non tree edge followed from frame.
+// In an undirected search every edge is classified once, from the endpoint that is discovered later,
+// so edges leading to finished nodes and the tree edge leading back to the parent return nil.
+func classifyEdge(frame *depthFirstFrame, next adjacentEdge, result DepthFirstSearchResult, options DepthFirstSearchOptions) EdgeFunction {
+	from := frame.node.GetID()
+	_, finished := result.Finish[next.To]
+	if options.IsUndirected {
+		if finished {
+			return nil
 		}
-		if options.IsUndirected {
-			edges, _ = node.GetIncidentEdges()
+		if parent, ok := result.Parents[from]; ok && parent.GetID() == next.Edge.GetID() {
+			return nil
 		}
-		if options.BeforeRecursion != nil {
-			options.BeforeRecursion(node)
+		return options.BackEdge
+	}
+	if !finished {
+		return options.BackEdge
+	}
+	if result.Discovery[from] < result.Discovery[next.To] {
+		return options.ForwardEdge
+	}
+	return options.CrossEdge
+}
+
+func depthFirstSearchHelper(ctx context.Context, g Graph, root Node, time *int, result DepthFirstSearchResult, options DepthFirstSearchOptions) (TraversalControl, error) {
+	stack := make([]*depthFirstFrame, 0)
+	discover := func(node Node) (TraversalControl, error) {
+		*time++
+		result.Discovery[node.GetID()] = *time
+		control, err := callNodeFunction(options.BeforeRecursion, node)
+		if err != nil || control == Stop {
+			return Stop, err
 		}
-		for _, edge := range edges {
-			other_node, _ := edge.GetTo()
-			if options.ReverseGraph {
-				other_node, _ = edge.GetFrom()
+		frame := &depthFirstFrame{node: node}
+		if control != SkipChildren {
+			frame.adjacent, err = getAdjacentEdges(node, !options.IsUndirected, options.ReverseGraph)
+			if err != nil {
+				return Stop, err
 			}
-			if options.IsUndirected {
-				nodes, _ := edge.GetNodes()
-				if len(nodes) == 1 {
-					other_node = nodes[0]
-				} else if nodes[0].GetID() != node.GetID() {
-					other_node = nodes[0]
-				} else {
-					other_node = nodes[1]
+		}
+		stack = append(stack, frame)
+		return Continue, nil
+	}
+
+	if _, reached := result.Discovery[root.GetID()]; reached {
+		return Continue, nil
+	}
+	if control, err := discover(root); err != nil || control == Stop {
+		return Stop, err
+	}
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return Stop, err
+		}
+		frame := stack[len(stack)-1]
+		if frame.next < len(frame.adjacent) {
+			next := frame.adjacent[frame.next]
+			frame.next++
+			if _, reached := result.Discovery[next.To]; reached {
+				control, err := callEdgeFunction(classifyEdge(frame, next, result, options), next.Edge)
+				if err != nil || control == Stop {
+					return Stop, err
 				}
+				continue
+			}
+			control, err := callEdgeFunction(options.TreeEdge, next.Edge)
+			if err != nil || control == Stop {
+				return Stop, err
+			}
+			if control == SkipChildren {
+				continue
 			}
-			depthFirstSearchHelper(other_node, visited, options)
+			nextNode, err := g.GetNode(next.To)
+			if err != nil {
+				return Stop, err
+			}
+			result.Parents[next.To] = next.Edge
+			if control, err := discover(nextNode); err != nil || control == Stop {
+				return Stop, err
+			}
+			continue
 		}
-		if options.AfterRecursion != nil {
-			options.AfterRecursion(node)
+		stack = stack[:len(stack)-1]
+		*time++
+		result.Finish[frame.node.GetID()] = *time
+		control, err := callNodeFunction(options.AfterRecursion, frame.node)
+		if err != nil || control == Stop {
+			return Stop, err
 		}
 	}
+	return Continue, nil
 }
 
-func DepthFirstSearch(g Graph, options DepthFirstSearchOptions) {
+// DepthFirstSearch is DepthFirstSearchWithContext without cancellation.
+func DepthFirstSearch(g Graph, options DepthFirstSearchOptions) (DepthFirstSearchResult, error) {
+	return DepthFirstSearchWithContext(context.Background(), g, options)
+}
+
+// DepthFirstSearchWithContext visits every node of g depth first, starting a new search
+// from each node in ascending id order (or CustomOrder) that has not been reached yet.
+// Every followed edge is passed to one of the edge hooks according to its type.
+// The search is iterative, so deep graphs do not grow the call stack.
+// It returns the first error from the graph or a hook, or the context's error once it is cancelled,
+// along with the part of the result computed so far.
+func DepthFirstSearchWithContext(ctx context.Context, g Graph, options DepthFirstSearchOptions) (DepthFirstSearchResult, error) {
 	if !g.IsDirected() {
 		options.IsUndirected = true
 	}
-	visited := make(map[NodeID]bool)
-	allNodes, _ := g.GetNodes()
-	for _, node := range allNodes {
-		visited[node.GetID()] = false
+	result := DepthFirstSearchResult{
+		Discovery: make(map[NodeID]int),
+		Finish:    make(map[NodeID]int),
+		Parents:   make(map[NodeID]Edge),
+	}
+	allNodes, err := g.GetNodes()
+	if err != nil {
+		return result, err
 	}
 	if options.CustomOrder != nil {
 		allNodes = options.CustomOrder
 	}
+	time := 0
 	for _, node := range allNodes {
-		if options.BeforeSearch != nil {
-			options.BeforeSearch(node)
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		control, err := callNodeFunction(options.BeforeSearch, node)
+		if err != nil || control == Stop {
+			return result, err
+		}
+		if control != SkipChildren {
+			control, err = depthFirstSearchHelper(ctx, g, node, &time, result, options)
+			if err != nil || control == Stop {
+				return result, err
+			}
 		}
-		depthFirstSearchHelper(node, visited, options)
-		if options.AfterSearch != nil {
-			options.AfterSearch(node)
+		control, err = callNodeFunction(options.AfterSearch, node)
+		if err != nil || control == Stop {
+			return result, err
 		}
 	}
+	return result, nil
 }
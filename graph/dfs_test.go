@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildDepthFirstGraph builds 1 -> {2, 4}, 2 -> 3, 4 -> 3 and 5 -> 1.
+func buildDepthFirstGraph(t *testing.T, bo BuilderOptions) Graph {
+	gb := NewGraphBuilder(bo)
+	for i := 1; i < 6; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(1, 4)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(4, 3)
+	gb.AddEdge(5, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func recordNodes(ids *[]NodeID) NodeFunction {
+	return func(n Node) (TraversalControl, error) {
+		*ids = append(*ids, n.GetID())
+		return Continue, nil
+	}
+}
+
+func Test_DepthFirstSearch_Order(t *testing.T) {
+	graph := buildDepthFirstGraph(t, BuilderOptions{IsDirected: true})
+	before := make([]NodeID, 0)
+	after := make([]NodeID, 0)
+	roots := make([]NodeID, 0)
+	_, err := DepthFirstSearch(graph, DepthFirstSearchOptions{
+		BeforeRecursion: recordNodes(&before),
+		AfterRecursion:  recordNodes(&after),
+		BeforeSearch:    recordNodes(&roots),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3, 4, 5}, before)
+	assert.Equal(t, []NodeID{3, 2, 4, 1, 5}, after)
+	assert.Equal(t, []NodeID{1, 2, 3, 4, 5}, roots)
+}
+
+func Test_DepthFirstSearch_Undirected(t *testing.T) {
+	graph := buildDepthFirstGraph(t, BuilderOptions{})
+	before := make([]NodeID, 0)
+	_, err := DepthFirstSearch(graph, DepthFirstSearchOptions{
+		BeforeRecursion: recordNodes(&before),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3, 4, 5}, before)
+
+	after := make([]NodeID, 0)
+	_, err = DepthFirstSearch(graph, DepthFirstSearchOptions{
+		AfterRecursion: recordNodes(&after),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{4, 3, 2, 5, 1}, after)
+}
+
+func Test_DepthFirstSearch_ReverseGraph(t *testing.T) {
+	graph := buildDepthFirstGraph(t, BuilderOptions{IsDirected: true})
+	start, err := graph.GetNode(3)
+	assert.NoError(t, err)
+	before := make([]NodeID, 0)
+	_, err = DepthFirstSearch(graph, DepthFirstSearchOptions{
+		CustomOrder:     []Node{start},
+		ReverseGraph:    true,
+		BeforeRecursion: recordNodes(&before),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{3, 2, 1, 5, 4}, before)
+}
+
+func Test_DepthFirstSearch_Control(t *testing.T) {
+	graph := buildDepthFirstGraph(t, BuilderOptions{IsDirected: true})
+	before := make([]NodeID, 0)
+	_, err := DepthFirstSearch(graph, DepthFirstSearchOptions{
+		BeforeRecursion: func(n Node) (TraversalControl, error) {
+			before = append(before, n.GetID())
+			if n.GetID() == 2 {
+				return SkipChildren, nil
+			}
+			if n.GetID() == 4 {
+				return Stop, nil
+			}
+			return Continue, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 4}, before)
+
+	before = make([]NodeID, 0)
+	_, err = DepthFirstSearch(graph, DepthFirstSearchOptions{
+		BeforeRecursion: recordNodes(&before),
+		BeforeSearch: func(n Node) (TraversalControl, error) {
+			if n.GetID() == 1 {
+				return SkipChildren, nil
+			}
+			return Continue, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{2, 3, 4, 5, 1}, before)
+}
+
+func Test_DepthFirstSearch_Error(t *testing.T) {
+	graph := buildDepthFirstGraph(t, BuilderOptions{IsDirected: true})
+	hookError := errors.New("hook error")
+	after := make([]NodeID, 0)
+	_, err := DepthFirstSearch(graph, DepthFirstSearchOptions{
+		AfterRecursion: func(n Node) (TraversalControl, error) {
+			after = append(after, n.GetID())
+			if n.GetID() == 2 {
+				return Continue, hookError
+			}
+			return Continue, nil
+		},
+	})
+	assert.ErrorIs(t, err, hookError)
+	assert.Equal(t, []NodeID{3, 2}, after)
+}
+
+func Test_DepthFirstSearch_Cancelled(t *testing.T) {
+	graph := buildDepthFirstGraph(t, BuilderOptions{IsDirected: true})
+	ctx, cancel := context.WithCancel(context.Background())
+	before := make([]NodeID, 0)
+	_, err := DepthFirstSearchWithContext(ctx, graph, DepthFirstSearchOptions{
+		BeforeRecursion: func(n Node) (TraversalControl, error) {
+			before = append(before, n.GetID())
+			cancel()
+			return Continue, nil
+		},
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []NodeID{1}, before)
+}
+
+func Test_DepthFirstSearch_LongPath(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	length := 100000
+	for i := 0; i < length; i++ {
+		gb.AddNode(NodeID(i))
+		if i > 0 {
+			gb.AddEdge(NodeID(i-1), NodeID(i))
+		}
+	}
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	count := 0
+	_, err = DepthFirstSearch(graph, DepthFirstSearchOptions{
+		AfterRecursion: func(n Node) (TraversalControl, error) {
+			if count == 0 {
+				assert.Equal(t, NodeID(length-1), n.GetID())
+			}
+			count++
+			return Continue, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, length, count)
+}
+
+func recordEdges(ids *[]EdgeID) EdgeFunction {
+	return func(e Edge) (TraversalControl, error) {
+		*ids = append(*ids, e.GetID())
+		return Continue, nil
+	}
+}
+
+// buildClassifiedGraph builds 1 -> {2, 3}, 2 -> 3, 3 -> 1 and 4 -> 2.
+func buildClassifiedGraph(t *testing.T, bo BuilderOptions) Graph {
+	gb := NewGraphBuilder(bo)
+	for i := 1; i < 5; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 1)
+	gb.AddEdge(1, 3)
+	gb.AddEdge(4, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func Test_DepthFirstSearch_EdgeClassification(t *testing.T) {
+	graph := buildClassifiedGraph(t, BuilderOptions{IsDirected: true})
+	tree, back, forward, cross := make([]EdgeID, 0), make([]EdgeID, 0), make([]EdgeID, 0), make([]EdgeID, 0)
+	result, err := DepthFirstSearch(graph, DepthFirstSearchOptions{
+		TreeEdge:    recordEdges(&tree),
+		BackEdge:    recordEdges(&back),
+		ForwardEdge: recordEdges(&forward),
+		CrossEdge:   recordEdges(&cross),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 1, To: 2}, {From: 2, To: 3}}, tree)
+	assert.Equal(t, []EdgeID{{From: 3, To: 1}}, back)
+	assert.Equal(t, []EdgeID{{From: 1, To: 3}}, forward)
+	assert.Equal(t, []EdgeID{{From: 4, To: 2}}, cross)
+	assert.Equal(t, map[NodeID]int{1: 1, 2: 2, 3: 3, 4: 7}, result.Discovery)
+	assert.Equal(t, map[NodeID]int{3: 4, 2: 5, 1: 6, 4: 8}, result.Finish)
+	assert.Len(t, result.Parents, 2)
+	assert.Equal(t, EdgeID{From: 2, To: 3}, result.Parents[3].GetID())
+}
+
+func Test_DepthFirstSearch_EdgeClassificationUndirected(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 5; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(1, 3)
+	gb.AddEdge(4, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	tree, back, other := make([]EdgeID, 0), make([]EdgeID, 0), make([]EdgeID, 0)
+	result, err := DepthFirstSearch(graph, DepthFirstSearchOptions{
+		TreeEdge:    recordEdges(&tree),
+		BackEdge:    recordEdges(&back),
+		ForwardEdge: recordEdges(&other),
+		CrossEdge:   recordEdges(&other),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 1, To: 2}, {From: 2, To: 3}, {From: 2, To: 4}}, tree)
+	assert.Equal(t, []EdgeID{{From: 1, To: 3}}, back)
+	assert.Empty(t, other)
+	assert.Equal(t, map[NodeID]int{1: 1, 2: 2, 3: 3, 4: 5}, result.Discovery)
+	assert.Equal(t, map[NodeID]int{3: 4, 4: 6, 2: 7, 1: 8}, result.Finish)
+	assert.Equal(t, EdgeID{From: 2, To: 4}, result.Parents[4].GetID())
+}
+
+func Test_DepthFirstSearch_EdgeClassificationParallel(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	gb.AddEdge(1, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	tree, back := make([]EdgeID, 0), make([]EdgeID, 0)
+	_, err = DepthFirstSearch(graph, DepthFirstSearchOptions{
+		TreeEdge: recordEdges(&tree),
+		BackEdge: recordEdges(&back),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 1, To: 2}}, tree)
+	assert.Equal(t, []EdgeID{{From: 1, To: 2, Index: 1}}, back)
+}
+
+func Test_DepthFirstSearch_SkipTreeEdge(t *testing.T) {
+	graph := buildClassifiedGraph(t, BuilderOptions{IsDirected: true})
+	result, err := DepthFirstSearch(graph, DepthFirstSearchOptions{
+		TreeEdge: func(e Edge) (TraversalControl, error) {
+			if e.GetID() == (EdgeID{From: 1, To: 2}) {
+				return SkipChildren, nil
+			}
+			return Continue, nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, EdgeID{From: 1, To: 3}, result.Parents[3].GetID())
+	assert.NotContains(t, result.Parents, NodeID(2))
+	assert.Equal(t, map[NodeID]int{1: 1, 3: 2, 2: 5, 4: 7}, result.Discovery)
+}
@@ -0,0 +1,79 @@
+package graph
+
+// DisjointSet keeps track of which nodes have been joined together (union-find).
+// Nodes are added the first time they are used, each in its own set.
+// Sets are merged by size and paths are compressed on every lookup,
+// so any sequence of operations runs in nearly linear time.
+type DisjointSet struct {
+	parents map[NodeID]NodeID
+	sizes   map[NodeID]int
+	count   int
+}
+
+// NewDisjointSet returns a disjoint set holding each of the given ids in its own set.
+func NewDisjointSet(ids ...NodeID) *DisjointSet {
+	ds := &DisjointSet{
+		parents: make(map[NodeID]NodeID),
+		sizes:   make(map[NodeID]int),
+	}
+	for _, id := range ids {
+		ds.Add(id)
+	}
+	return ds
+}
+
+// Add puts id in a new set of its own unless it is already in one.
+func (ds *DisjointSet) Add(id NodeID) {
+	if _, ok := ds.parents[id]; ok {
+		return
+	}
+	ds.parents[id] = id
+	ds.sizes[id] = 1
+	ds.count++
+}
+
+// Find returns the representative of the set containing id.
+// Two ids are in the same set exactly when they have the same representative.
+func (ds *DisjointSet) Find(id NodeID) NodeID {
+	ds.Add(id)
+	root := id
+	for ds.parents[root] != root {
+		root = ds.parents[root]
+	}
+	for id != root {
+		id, ds.parents[id] = ds.parents[id], root
+	}
+	return root
+}
+
+// Union merges the sets containing a and b.
+// It returns false if they were already in the same set.
+func (ds *DisjointSet) Union(a, b NodeID) bool {
+	a, b = ds.Find(a), ds.Find(b)
+	if a == b {
+		return false
+	}
+	if ds.sizes[a] < ds.sizes[b] {
+		a, b = b, a
+	}
+	ds.parents[b] = a
+	ds.sizes[a] += ds.sizes[b]
+	delete(ds.sizes, b)
+	ds.count--
+	return true
+}
+
+// Connected reports whether a and b are in the same set.
+func (ds *DisjointSet) Connected(a, b NodeID) bool {
+	return ds.Find(a) == ds.Find(b)
+}
+
+// Size returns the number of ids in the set containing id.
+func (ds *DisjointSet) Size(id NodeID) int {
+	return ds.sizes[ds.Find(id)]
+}
+
+// Count returns the number of disjoint sets.
+func (ds *DisjointSet) Count() int {
+	return ds.count
+}
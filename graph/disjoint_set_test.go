@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_DisjointSet(t *testing.T) {
+	ds := NewDisjointSet(1, 2, 3, 4)
+	assert.Equal(t, 4, ds.Count())
+	assert.False(t, ds.Connected(1, 2))
+
+	assert.True(t, ds.Union(1, 2))
+	assert.True(t, ds.Union(3, 4))
+	assert.False(t, ds.Union(2, 1))
+	assert.Equal(t, 2, ds.Count())
+	assert.True(t, ds.Connected(1, 2))
+	assert.False(t, ds.Connected(2, 3))
+
+	assert.True(t, ds.Union(2, 4))
+	assert.True(t, ds.Connected(1, 3))
+	assert.Equal(t, 4, ds.Size(3))
+	assert.Equal(t, 1, ds.Count())
+}
+
+func Test_DisjointSet_AddOnUse(t *testing.T) {
+	ds := NewDisjointSet()
+	assert.Equal(t, NodeID(5), ds.Find(5))
+	assert.Equal(t, 1, ds.Count())
+	ds.Add(5)
+	assert.Equal(t, 1, ds.Size(5))
+	assert.True(t, ds.Union(5, 6))
+	assert.Equal(t, 1, ds.Count())
+	assert.Equal(t, 2, ds.Size(6))
+}
+
+func Test_DisjointSet_PathCompression(t *testing.T) {
+	ds := NewDisjointSet()
+	for i := 1; i < 100; i++ {
+		ds.Union(NodeID(i-1), NodeID(i))
+	}
+	root := ds.Find(99)
+	for i := 0; i < 100; i++ {
+		ds.Find(NodeID(i))
+		assert.Equal(t, root, ds.parents[NodeID(i)])
+	}
+}
@@ -1,2 +1,28 @@
 // Package graph contains a graph builder for creating directed or undirected graphs.
+//
+// Graphs built with NewTypedGraphBuilder store statically typed node and edge values.
+// Graph, Node, Edge and GraphBuilder are aliases for the untyped (interface{}) variants,
+// and Untyped converts a typed graph so it can be used with the search algorithms.
+// NewKeyedGraphBuilder and NewTypedKeyedGraphBuilder identify nodes with any comparable key
+// and build graphs that convert between those keys and NodeIDs.
+// Operators such as Reverse, Union and CartesianProduct store their result like their (first) graph,
+// so a graph built with CSRStorage stays in CSR form.
+//
+// # Concurrency
+//
+// A graph returned by Build never changes after it is built, so any number of goroutines
+// can read it and run algorithms on it at the same time. The nodes and edges it returns are read-only too.
+// Views and the graphs returned by Untyped read through to the graph they wrap
+// and are as safe as that graph, provided their predicates are.
+//
+// A MutableGraph is not safe for concurrent use: an edit must not run at the same time as any other call.
+// Freeze copies it into a graph that can be shared while the original keeps changing,
+// and storing that copy in an atomic.Value lets readers swap to a rebuilt graph without locking.
+//
+// Builders are not safe for concurrent use either, except for the ones created with NewConcurrentGraphBuilder
+// and NewTypedConcurrentGraphBuilder.
+//
+// Algorithms do not start goroutines unless their name says they are parallel or they take ParallelOptions,
+// such as ParallelBreadthFirstSearch, AllPairsDistances and AllPairsShortestPaths.
+// Those call the graph and any callback from several goroutines at once.
 package graph
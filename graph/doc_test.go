@@ -15,3 +15,20 @@ func Example() {
 		fmt.Println(graph)
 	}
 }
+
+func ExampleNewTypedGraphBuilder() {
+	// create an undirected graph with string nodes and int edges
+	gb := NewTypedGraphBuilder[string, int]()
+	gb.AddNode(1, "node-val1")
+	gb.AddNode(2, "node-val2")
+	gb.AddEdge(1, 2, 12)
+	graph, err := gb.Build()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	edge, _ := graph.GetEdge(2, 1)
+	value, _ := edge.GetValue()
+	fmt.Println(value + 1)
+	// Output: 13
+}
@@ -0,0 +1,463 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DOTOptions configures WriteDOT.
+type DOTOptions struct {
+	// Name is the name of the graph. If empty, the graph has no name.
+	Name string
+	// NodeLabel returns the label attribute of a node. If nil, nodes have no label.
+	NodeLabel func(Node) (string, error)
+	// EdgeLabel returns the label attribute of an edge. If nil, edges have no label.
+	EdgeLabel func(Edge) (string, error)
+}
+
+// WriteDOT writes g in the Graphviz DOT language as a digraph or a graph depending on IsDirected,
+// with one statement per node and per edge sorted by id.
+func WriteDOT(w io.Writer, g Graph, options DOTOptions) error {
+	bw := bufio.NewWriter(w)
+	keyword, edgeOp := "graph", "--"
+	if g.IsDirected() {
+		keyword, edgeOp = "digraph", "->"
+	}
+	if options.Name != "" {
+		fmt.Fprintf(bw, "%s %s {\n", keyword, quoteDOT(options.Name))
+	} else {
+		fmt.Fprintf(bw, "%s {\n", keyword)
+	}
+
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		fmt.Fprintf(bw, "\t%d", node.GetID())
+		if options.NodeLabel != nil {
+			label, err := options.NodeLabel(node)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(bw, " [label=%s]", quoteDOT(label))
+		}
+		fmt.Fprint(bw, ";\n")
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(bw, "\t%d %s %d", edge.GetID().From, edgeOp, edge.GetID().To)
+		if options.EdgeLabel != nil {
+			label, err := options.EdgeLabel(edge)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(bw, " [label=%s]", quoteDOT(label))
+		}
+		fmt.Fprint(bw, ";\n")
+	}
+	fmt.Fprint(bw, "}\n")
+	return bw.Flush()
+}
+
+// quoteDOT turns s into a DOT string literal.
+func quoteDOT(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// ReadDOT parses a graph written in the Graphviz DOT language and builds it with a graph builder using the given options.
+// IsDirected is taken from the graph keyword. Node ids must be integers.
+// The label attribute of a node or edge becomes its string value and all other attributes are ignored.
+// Nodes that only appear in edges are added without a value, and a node declared more than once
+// keeps the last label it was given. Subgraphs, ports and HTML strings are not supported.
+func ReadDOT(r io.Reader, bo ...BuilderOptions) (Graph, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotParser{lexer: dotLexer{input: []rune(string(data)), line: 1}}
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	builderOptions := BuilderOptions{}
+	if len(bo) == 1 {
+		builderOptions = bo[0]
+	}
+	builderOptions.IsDirected = p.isDirected
+	gb := NewGraphBuilder(builderOptions)
+	for _, id := range p.nodeOrder {
+		if label, ok := p.nodeLabels[id]; ok {
+			gb.AddNode(id, label)
+		} else {
+			gb.AddNode(id)
+		}
+	}
+	for _, edge := range p.edges {
+		if edge.hasLabel {
+			gb.AddEdge(edge.from, edge.to, edge.label)
+		} else {
+			gb.AddEdge(edge.from, edge.to)
+		}
+	}
+	return gb.Build()
+}
+
+type dotTokenKind int
+
+const (
+	dotEOF dotTokenKind = iota
+	dotID
+	dotQuoted
+	dotPunctuation
+)
+
+type dotToken struct {
+	kind dotTokenKind
+	text string
+	line int
+}
+
+// dotLexer splits DOT source into identifiers, quoted strings and punctuation, skipping whitespace and comments.
+type dotLexer struct {
+	input []rune
+	pos   int
+	line  int
+}
+
+func (l *dotLexer) peekRune(offset int) rune {
+	if l.pos+offset >= len(l.input) {
+		return 0
+	}
+	return l.input[l.pos+offset]
+}
+
+func (l *dotLexer) skipSpaceAndComments() error {
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case unicode.IsSpace(c):
+			l.pos++
+		case c == '#' || (c == '/' && l.peekRune(1) == '/'):
+			for l.pos < len(l.input) && l.input[l.pos] != '\n' {
+				l.pos++
+			}
+		case c == '/' && l.peekRune(1) == '*':
+			start := l.line
+			l.pos += 2
+			for !(l.peekRune(0) == '*' && l.peekRune(1) == '/') {
+				if l.pos >= len(l.input) {
+					return DOTSyntaxError{Line: start, Message: "unterminated comment"}
+				}
+				if l.input[l.pos] == '\n' {
+					l.line++
+				}
+				l.pos++
+			}
+			l.pos += 2
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func isDOTIDRune(c rune) bool {
+	return c == '_' || c == '.' || c == '-' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+func (l *dotLexer) next() (dotToken, error) {
+	if err := l.skipSpaceAndComments(); err != nil {
+		return dotToken{}, err
+	}
+	if l.pos >= len(l.input) {
+		return dotToken{kind: dotEOF, line: l.line}, nil
+	}
+	c := l.input[l.pos]
+	switch {
+	case c == '-' && (l.peekRune(1) == '>' || l.peekRune(1) == '-'):
+		l.pos += 2
+		return dotToken{kind: dotPunctuation, text: string([]rune{c, l.input[l.pos-1]}), line: l.line}, nil
+	case strings.ContainsRune("{}[];,=:", c):
+		l.pos++
+		return dotToken{kind: dotPunctuation, text: string(c), line: l.line}, nil
+	case c == '"':
+		return l.quoted()
+	case isDOTIDRune(c):
+		start := l.pos
+		for l.pos < len(l.input) && isDOTIDRune(l.input[l.pos]) {
+			// stop before an edge operator written without spaces, as in 1->2
+			if l.input[l.pos] == '-' && (l.peekRune(1) == '>' || l.peekRune(1) == '-') {
+				break
+			}
+			l.pos++
+		}
+		return dotToken{kind: dotID, text: string(l.input[start:l.pos]), line: l.line}, nil
+	}
+	return dotToken{}, DOTSyntaxError{Line: l.line, Message: fmt.Sprintf("unexpected character %q", c)}
+}
+
+func (l *dotLexer) quoted() (dotToken, error) {
+	start := l.line
+	l.pos++
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.input) {
+			return dotToken{}, DOTSyntaxError{Line: start, Message: "unterminated string"}
+		}
+		c := l.input[l.pos]
+		l.pos++
+		switch {
+		case c == '"':
+			return dotToken{kind: dotQuoted, text: sb.String(), line: start}, nil
+		case c == '\\' && l.pos < len(l.input):
+			escaped := l.input[l.pos]
+			l.pos++
+			switch escaped {
+			case '"', '\\':
+				sb.WriteRune(escaped)
+			case 'n':
+				sb.WriteRune('\n')
+			case '\n':
+				// a backslash before a newline continues the string on the next line
+				l.line++
+			default:
+				sb.WriteRune('\\')
+				sb.WriteRune(escaped)
+			}
+		default:
+			if c == '\n' {
+				l.line++
+			}
+			sb.WriteRune(c)
+		}
+	}
+}
+
+type dotEdge struct {
+	from     NodeID
+	to       NodeID
+	label    string
+	hasLabel bool
+}
+
+// dotParser reads the statements of a single graph, remembering nodes in the order they first appear.
+type dotParser struct {
+	lexer      dotLexer
+	token      dotToken
+	isDirected bool
+	nodeOrder  []NodeID
+	seen       map[NodeID]bool
+	nodeLabels map[NodeID]string
+	edges      []dotEdge
+}
+
+func (p *dotParser) advance() error {
+	token, err := p.lexer.next()
+	p.token = token
+	return err
+}
+
+func (p *dotParser) isPunctuation(text string) bool {
+	return p.token.kind == dotPunctuation && p.token.text == text
+}
+
+func (p *dotParser) isKeyword(keyword string) bool {
+	return p.token.kind == dotID && strings.EqualFold(p.token.text, keyword)
+}
+
+func (p *dotParser) expect(text string) error {
+	if !p.isPunctuation(text) {
+		return p.unexpected(fmt.Sprintf("%q", text))
+	}
+	return p.advance()
+}
+
+func (p *dotParser) unexpected(expected string) error {
+	found := fmt.Sprintf("%q", p.token.text)
+	if p.token.kind == dotEOF {
+		found = "end of input"
+	}
+	return DOTSyntaxError{Line: p.token.line, Message: fmt.Sprintf("expected %s but found %s", expected, found)}
+}
+
+func (p *dotParser) parse() error {
+	p.nodeOrder = make([]NodeID, 0)
+	p.seen = make(map[NodeID]bool)
+	p.nodeLabels = make(map[NodeID]string)
+	p.edges = make([]dotEdge, 0)
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.isKeyword("strict") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.isKeyword("digraph"):
+		p.isDirected = true
+	case p.isKeyword("graph"):
+		p.isDirected = false
+	default:
+		return p.unexpected("graph or digraph")
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.token.kind == dotID || p.token.kind == dotQuoted {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.isPunctuation("}") {
+		if err := p.statement(); err != nil {
+			return err
+		}
+		if p.isPunctuation(";") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := p.advance(); err != nil {
+		return err
+	}
+	if p.token.kind != dotEOF {
+		return p.unexpected("end of input")
+	}
+	return nil
+}
+
+func (p *dotParser) statement() error {
+	if p.token.kind != dotID && p.token.kind != dotQuoted {
+		return p.unexpected("a statement")
+	}
+	// attribute statements such as node [shape=box] set defaults, which are ignored
+	if p.token.kind == dotID && (p.isKeyword("graph") || p.isKeyword("node") || p.isKeyword("edge")) {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		_, _, err := p.attributes()
+		return err
+	}
+	first := p.token.text
+	if err := p.advance(); err != nil {
+		return err
+	}
+	// graph attributes such as rankdir=LR are ignored too
+	if p.isPunctuation("=") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if p.token.kind != dotID && p.token.kind != dotQuoted {
+			return p.unexpected("an attribute value")
+		}
+		return p.advance()
+	}
+
+	ids := []string{first}
+	edgeOp, wrongOp := "--", "->"
+	if p.isDirected {
+		edgeOp, wrongOp = "->", "--"
+	}
+	for p.isPunctuation(edgeOp) || p.isPunctuation(wrongOp) {
+		if p.isPunctuation(wrongOp) {
+			return DOTSyntaxError{Line: p.token.line, Message: fmt.Sprintf("edge operator %s cannot be used in this graph", wrongOp)}
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if p.token.kind != dotID && p.token.kind != dotQuoted {
+			return p.unexpected("a node id")
+		}
+		ids = append(ids, p.token.text)
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	if p.isPunctuation(":") {
+		return DOTSyntaxError{Line: p.token.line, Message: "ports are not supported"}
+	}
+	label, hasLabel, err := p.attributes()
+	if err != nil {
+		return err
+	}
+
+	nodeIDs := make([]NodeID, 0, len(ids))
+	for _, text := range ids {
+		id, err := strconv.Atoi(text)
+		if err != nil {
+			return DOTSyntaxError{Line: p.token.line, Message: fmt.Sprintf("node id %q is not an integer", text)}
+		}
+		if !p.seen[NodeID(id)] {
+			p.seen[NodeID(id)] = true
+			p.nodeOrder = append(p.nodeOrder, NodeID(id))
+		}
+		nodeIDs = append(nodeIDs, NodeID(id))
+	}
+	if len(nodeIDs) == 1 {
+		if hasLabel {
+			p.nodeLabels[nodeIDs[0]] = label
+		}
+		return nil
+	}
+	for i := 1; i < len(nodeIDs); i++ {
+		p.edges = append(p.edges, dotEdge{from: nodeIDs[i-1], to: nodeIDs[i], label: label, hasLabel: hasLabel})
+	}
+	return nil
+}
+
+// attributes reads any number of attribute lists and returns the last label in them.
+func (p *dotParser) attributes() (string, bool, error) {
+	label, hasLabel := "", false
+	for p.isPunctuation("[") {
+		if err := p.advance(); err != nil {
+			return "", false, err
+		}
+		for !p.isPunctuation("]") {
+			if p.token.kind != dotID && p.token.kind != dotQuoted {
+				return "", false, p.unexpected("an attribute name")
+			}
+			name := p.token.text
+			if err := p.advance(); err != nil {
+				return "", false, err
+			}
+			if err := p.expect("="); err != nil {
+				return "", false, err
+			}
+			if p.token.kind != dotID && p.token.kind != dotQuoted {
+				return "", false, p.unexpected("an attribute value")
+			}
+			if name == "label" {
+				label, hasLabel = p.token.text, true
+			}
+			if err := p.advance(); err != nil {
+				return "", false, err
+			}
+			if p.isPunctuation(",") || p.isPunctuation(";") {
+				if err := p.advance(); err != nil {
+					return "", false, err
+				}
+			}
+		}
+		if err := p.advance(); err != nil {
+			return "", false, err
+		}
+	}
+	return label, hasLabel, nil
+}
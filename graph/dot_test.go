@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func nodeValueLabel(n Node) (string, error) {
+	value, err := n.GetValue()
+	return fmt.Sprint(value), err
+}
+
+func edgeValueLabel(e Edge) (string, error) {
+	value, err := e.GetValue()
+	return fmt.Sprint(value), err
+}
+
+func Test_WriteDOT_Directed(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1, "north \"keep\"")
+	gb.AddNode(2, "south")
+	gb.AddEdge(2, 1, 4)
+	gb.AddEdge(1, 2, 3)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	err = WriteDOT(&buf, graph, DOTOptions{Name: "board", NodeLabel: nodeValueLabel, EdgeLabel: edgeValueLabel})
+	assert.NoError(t, err)
+	expected_dot := "digraph \"board\" {\n" +
+		"\t1 [label=\"north \\\"keep\\\"\"];\n" +
+		"\t2 [label=\"south\"];\n" +
+		"\t1 -> 2 [label=\"3\"];\n" +
+		"\t2 -> 1 [label=\"4\"];\n" +
+		"}\n"
+	assert.Equal(t, expected_dot, buf.String())
+
+	actual_graph, err := ReadDOT(&buf)
+	assert.NoError(t, err)
+	assert.True(t, actual_graph.IsDirected())
+	node, err := actual_graph.GetNode(1)
+	assert.NoError(t, err)
+	value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "north \"keep\"", value)
+	edge, err := actual_graph.GetEdge(2, 1)
+	assert.NoError(t, err)
+	value, err = edge.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "4", value)
+}
+
+func Test_WriteDOT_Undirected(t *testing.T) {
+	gb := NewGraphBuilder()
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(2, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	err = WriteDOT(&buf, graph, DOTOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, "graph {\n\t1;\n\t2;\n\t3;\n\t1 -- 2;\n}\n", buf.String())
+
+	actual_graph, err := ReadDOT(&buf)
+	assert.NoError(t, err)
+	AssertGraphEquals(t, graph, actual_graph)
+}
+
+func Test_ReadDOT(t *testing.T) {
+	dot := `strict digraph "G" {
+		// defaults and graph attributes are ignored
+		rankdir=LR;
+		node [shape=box, color="red"]
+		/* nodes can be declared
+		   more than once */
+		1 [label="one"]
+		1 [color=blue; label=uno]
+		# chains add an edge per step
+		1->2->3 [label="road", weight=2]
+		4
+	}`
+	actual_graph, err := ReadDOT(strings.NewReader(dot))
+	assert.NoError(t, err)
+	nodes, err := actual_graph.GetNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3, 4}, nodeIDs(nodes))
+	value, err := nodes[0].GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "uno", value)
+	_, err = nodes[1].GetValue()
+	assert.Error(t, err)
+	edges, err := actual_graph.GetEdges()
+	assert.NoError(t, err)
+	assert.Len(t, edges, 2)
+	value, err = edges[1].GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "road", value)
+}
+
+func Test_ReadDOT_Errors(t *testing.T) {
+	tests := map[string]string{
+		"graph { 1 -> 2 }":           "invalid DOT on line 1: edge operator -> cannot be used in this graph",
+		"digraph { a -> 2 }":         "invalid DOT on line 1: node id \"a\" is not an integer",
+		"digraph {\n 1 [label=\"x }": "invalid DOT on line 2: unterminated string",
+		"tree { }":                   "invalid DOT on line 1: expected graph or digraph but found \"tree\"",
+		"graph { 1 ":                 "invalid DOT on line 1: expected a statement but found end of input",
+		"graph { 1:n -- 2 }":         "invalid DOT on line 1: ports are not supported",
+	}
+	for dot, expected_error := range tests {
+		_, err := ReadDOT(strings.NewReader(dot))
+		assert.EqualError(t, err, expected_error, dot)
+	}
+
+	_, err := ReadDOT(strings.NewReader("graph { 1 -- 2; 2 -- 1 }"))
+	assert.ErrorIs(t, err, DuplicateEdgeError{FromID: 1, ToID: 2})
+	_, err = ReadDOT(strings.NewReader("graph { 1 -- 2; 2 -- 1 }"), BuilderOptions{AllowParallelEdges: true})
+	assert.NoError(t, err)
+}
@@ -1,44 +1,77 @@
 package graph
 
-// Edge represents an edge in a graph with access to its node endpoints.
+// EdgeID uniquely identifies an edge in a graph.
+// In an undirected graph, From is always less than or equal to To.
+// Index tells parallel edges between the same nodes apart and is 0 for the first edge.
+type EdgeID struct {
+	From  NodeID
+	To    NodeID
+	Index int
+}
+
+// lessEdgeID orders edge ids by their endpoints and then by index.
+func lessEdgeID(a, b EdgeID) bool {
+	if a.From != b.From {
+		return a.From < b.From
+	}
+	if a.To != b.To {
+		return a.To < b.To
+	}
+	return a.Index < b.Index
+}
+
+// Edge represents an edge in a graph whose node and edge values are untyped.
+type Edge = TypedEdge[interface{}, interface{}]
+
+// TypedEdge represents an edge in a graph with access to its node endpoints.
 // If its in a directed graph, it also has access to the node the edge comes from
-// and the node the edge goes into. It can also store a value.
-type Edge interface {
+// and the node the edge goes into. It can also store a value of type E.
+type TypedEdge[N, E any] interface {
+	// GetID returns the edge's unique identifier.
+	GetID() EdgeID
+
 	// GetTo returns the node the edge goes into in a directed graph.
 	// In a undirected graph, this returns a "cannot use this method" error.
-	GetTo() (Node, error)
+	GetTo() (TypedNode[N, E], error)
 
 	// GetFrom returns the node the edge comes from in a directed graph.
 	// In a undirected graph, this returns a "cannot use this method" error.
-	GetFrom() (Node, error)
+	GetFrom() (TypedNode[N, E], error)
 
 	// GetNodes returns the endpoint nodes of this edge.
 	// Nodes are sorted by id (ascending).
-	GetNodes() ([]Node, error)
+	GetNodes() ([]TypedNode[N, E], error)
 
 	// GetValue returns the value stored in this edge.
 	// If there is no value then this returns a "no value" error.
-	GetValue() (interface{}, error)
-	removeRef() Edge
+	GetValue() (E, error)
+	removeRef() TypedEdge[N, E]
 }
 
-type rawDirectedEdge struct {
+type rawDirectedEdge = directedEdge[interface{}, interface{}]
+
+type directedEdge[N, E any] struct {
 	From        NodeID
 	To          NodeID
-	RawGraphRef *rawDirectedGraph
-	Value       wrappedValue
+	Index       int
+	RawGraphRef *directedGraph[N, E]
+	Value       typedValue[E]
+}
+
+func (re directedEdge[N, E]) GetID() EdgeID {
+	return EdgeID{From: re.From, To: re.To, Index: re.Index}
 }
 
-func (re rawDirectedEdge) GetTo() (Node, error) {
+func (re directedEdge[N, E]) GetTo() (TypedNode[N, E], error) {
 	return re.RawGraphRef.GetNode(re.To)
 }
 
-func (re rawDirectedEdge) GetFrom() (Node, error) {
+func (re directedEdge[N, E]) GetFrom() (TypedNode[N, E], error) {
 	return re.RawGraphRef.GetNode(re.From)
 }
 
-func (re rawDirectedEdge) GetNodes() ([]Node, error) {
-	nodes := make([]Node, 0)
+func (re directedEdge[N, E]) GetNodes() ([]TypedNode[N, E], error) {
+	nodes := make([]TypedNode[N, E], 0)
 	from, err := re.GetFrom()
 	if err != nil {
 		return nodes, err
@@ -54,34 +87,42 @@ func (re rawDirectedEdge) GetNodes() ([]Node, error) {
 	return nodes, nil
 }
 
-func (re rawDirectedEdge) GetValue() (interface{}, error) {
+func (re directedEdge[N, E]) GetValue() (E, error) {
 	if !re.Value.HasValue {
-		return nil, noValueFoundInEdgeError{fromID: re.From, toID: re.To}
+		var zero E
+		return zero, NoValueFoundInEdgeError{FromID: re.From, ToID: re.To}
 	}
 	return re.Value.RawValue, nil
 }
 
-func (re rawDirectedEdge) removeRef() Edge {
+func (re directedEdge[N, E]) removeRef() TypedEdge[N, E] {
 	re.RawGraphRef = nil
 	return re
 }
 
-type rawUndirectedEdge struct {
+type rawUndirectedEdge = undirectedEdge[interface{}, interface{}]
+
+type undirectedEdge[N, E any] struct {
 	Nodes       [2]NodeID
-	RawGraphRef *rawUndirectedGraph
-	Value       wrappedValue
+	Index       int
+	RawGraphRef *undirectedGraph[N, E]
+	Value       typedValue[E]
+}
+
+func (re undirectedEdge[N, E]) GetID() EdgeID {
+	return EdgeID{From: re.Nodes[0], To: re.Nodes[1], Index: re.Index}
 }
 
-func (re rawUndirectedEdge) GetTo() (Node, error) {
-	return nil, cannotUseForUndirectedGraphError{"Edge.GetTo"}
+func (re undirectedEdge[N, E]) GetTo() (TypedNode[N, E], error) {
+	return nil, CannotUseForUndirectedGraphError{"Edge.GetTo"}
 }
 
-func (re rawUndirectedEdge) GetFrom() (Node, error) {
-	return nil, cannotUseForUndirectedGraphError{"Edge.GetFrom"}
+func (re undirectedEdge[N, E]) GetFrom() (TypedNode[N, E], error) {
+	return nil, CannotUseForUndirectedGraphError{"Edge.GetFrom"}
 }
 
-func (re rawUndirectedEdge) GetNodes() ([]Node, error) {
-	nodes := make([]Node, 0)
+func (re undirectedEdge[N, E]) GetNodes() ([]TypedNode[N, E], error) {
+	nodes := make([]TypedNode[N, E], 0)
 	node, err := re.RawGraphRef.GetNode(re.Nodes[0])
 	if err != nil {
 		return nodes, err
@@ -97,14 +138,15 @@ func (re rawUndirectedEdge) GetNodes() ([]Node, error) {
 	return nodes, nil
 }
 
-func (re rawUndirectedEdge) GetValue() (interface{}, error) {
+func (re undirectedEdge[N, E]) GetValue() (E, error) {
 	if !re.Value.HasValue {
-		return nil, noValueFoundInEdgeError{fromID: re.Nodes[0], toID: re.Nodes[1]}
+		var zero E
+		return zero, NoValueFoundInEdgeError{FromID: re.Nodes[0], ToID: re.Nodes[1]}
 	}
 	return re.Value.RawValue, nil
 }
 
-func (re rawUndirectedEdge) removeRef() Edge {
+func (re undirectedEdge[N, E]) removeRef() TypedEdge[N, E] {
 	re.RawGraphRef = nil
 	return re
 }
@@ -16,7 +16,7 @@ func Test_UndirectedEdge_GetTo(t *testing.T) {
 	edge, err := graph.GetEdge(1, 2)
 	assert.NoError(t, err)
 	_, err = edge.GetTo()
-	assert.ErrorIs(t, err, cannotUseForUndirectedGraphError{"Edge.GetTo"})
+	assert.ErrorIs(t, err, CannotUseForUndirectedGraphError{"Edge.GetTo"})
 }
 
 func Test_DirectedEdge_GetTo(t *testing.T) {
@@ -44,7 +44,7 @@ func Test_UndirectedEdge_GetFrom(t *testing.T) {
 	edge, err := graph.GetEdge(1, 2)
 	assert.NoError(t, err)
 	_, err = edge.GetFrom()
-	assert.ErrorIs(t, err, cannotUseForUndirectedGraphError{"Edge.GetFrom"})
+	assert.ErrorIs(t, err, CannotUseForUndirectedGraphError{"Edge.GetFrom"})
 }
 
 func Test_DirectedEdge_GetFrom(t *testing.T) {
@@ -146,7 +146,7 @@ func Test_UndirectedEdge_GetValue(t *testing.T) {
 	edge, err = graph.GetEdge(1, 3)
 	assert.NoError(t, err)
 	_, err = edge.GetValue()
-	assert.ErrorIs(t, err, noValueFoundInEdgeError{fromID: 1, toID: 3})
+	assert.ErrorIs(t, err, NoValueFoundInEdgeError{FromID: 1, ToID: 3})
 }
 
 func Test_DirectedEdge_GetValue(t *testing.T) {
@@ -167,5 +167,31 @@ func Test_DirectedEdge_GetValue(t *testing.T) {
 	edge, err = graph.GetEdge(1, 3)
 	assert.NoError(t, err)
 	_, err = edge.GetValue()
-	assert.ErrorIs(t, err, noValueFoundInEdgeError{fromID: 1, toID: 3})
+	assert.ErrorIs(t, err, NoValueFoundInEdgeError{FromID: 1, ToID: 3})
+}
+
+func Test_UndirectedEdge_GetID(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(2, 1)
+	gb.AddEdge(2, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	edges, err := graph.GetEdgesBetween(2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, EdgeID{From: 1, To: 2, Index: 0}, edges[0].GetID())
+	assert.Equal(t, EdgeID{From: 1, To: 2, Index: 1}, edges[1].GetID())
+}
+
+func Test_DirectedEdge_GetID(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(2, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	edge, err := graph.GetEdge(2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, EdgeID{From: 2, To: 1, Index: 0}, edge.GetID())
 }
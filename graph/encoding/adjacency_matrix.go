@@ -0,0 +1,138 @@
+package encoding
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/r0ddy/conquer/graph"
+)
+
+const adjacencyMatrixFormat = "adjacency matrix"
+
+// WriteAdjacencyMatrix writes g as a CSV adjacency matrix. The first row and the first column hold the node ids
+// sorted in ascending order and the cell in row a and column b describes the edge from a to b:
+// it is empty if there is no edge, the encoded edge value if there is an edge codec, and 1 otherwise.
+// With an edge codec every edge must have a value, since 1 would be read back as a value.
+// Encoded values that are empty or start with a quote are written quoted like Go strings.
+// The matrix of an undirected graph is symmetric. Node values and parallel edges cannot be written.
+func WriteAdjacencyMatrix(w io.Writer, g graph.Graph, options Options) error {
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return err
+	}
+	positions := make(map[graph.NodeID]int)
+	header := make([]string, 0, len(nodes)+1)
+	header = append(header, "")
+	for i, node := range nodes {
+		positions[node.GetID()] = i + 1
+		header = append(header, strconv.Itoa(int(node.GetID())))
+	}
+	rows := make([][]string, len(nodes))
+	for i, node := range nodes {
+		rows[i] = make([]string, len(nodes)+1)
+		rows[i][0] = strconv.Itoa(int(node.GetID()))
+	}
+
+	edges, err := g.GetEdges()
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		id := edge.GetID()
+		if id.Index > 0 {
+			return ParallelEdgesError{Format: adjacencyMatrixFormat, FromID: id.From, ToID: id.To}
+		}
+		text, hasValue, err := encodeEdgeValue(options.EdgeCodec, edge)
+		if err != nil {
+			return err
+		}
+		if options.EdgeCodec == nil {
+			text = "1"
+		} else if !hasValue {
+			return MissingEdgeValueError{Format: adjacencyMatrixFormat, FromID: id.From, ToID: id.To}
+		} else {
+			text = quoteValue(text, text == "")
+		}
+		rows[positions[id.From]-1][positions[id.To]] = text
+		if !g.IsDirected() {
+			rows[positions[id.To]-1][positions[id.From]] = text
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// ReadAdjacencyMatrix reads a graph written by WriteAdjacencyMatrix. Whether the graph is directed is taken
+// from the builder options; for undirected graphs only the cells on and above the diagonal are read.
+// Without an edge codec, cells that are empty or 0 mean there is no edge.
+// With one, only empty cells mean there is no edge and the others are decoded as the edge value
+// after unquoting them if they start with a quote.
+func ReadAdjacencyMatrix(r io.Reader, options Options) (graph.Graph, error) {
+	cr := csv.NewReader(r)
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, SyntaxError{Format: adjacencyMatrixFormat, Line: 1, Message: "missing header row"}
+	}
+	parseID := func(text string, line int) (graph.NodeID, error) {
+		id, err := strconv.Atoi(text)
+		if err != nil {
+			return 0, SyntaxError{Format: adjacencyMatrixFormat, Line: line, Message: fmt.Sprintf("node id %q is not an integer", text)}
+		}
+		return graph.NodeID(id), nil
+	}
+
+	gb := graph.NewGraphBuilder(options.BuilderOptions)
+	columns := make([]graph.NodeID, 0, len(records[0])-1)
+	for _, text := range records[0][1:] {
+		id, err := parseID(text, 1)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, id)
+		gb.AddNode(id)
+	}
+	if len(records)-1 != len(columns) {
+		return nil, SyntaxError{Format: adjacencyMatrixFormat, Line: len(records), Message: fmt.Sprintf("expected %d rows but found %d", len(columns), len(records)-1)}
+	}
+	for i, record := range records[1:] {
+		line := i + 2
+		from, err := parseID(record[0], line)
+		if err != nil {
+			return nil, err
+		}
+		if from != columns[i] {
+			return nil, SyntaxError{Format: adjacencyMatrixFormat, Line: line, Message: fmt.Sprintf("expected row for node %d but found %d", columns[i], from)}
+		}
+		for j, text := range record[1:] {
+			if (!options.BuilderOptions.IsDirected && j < i) || text == "" || (options.EdgeCodec == nil && text == "0") {
+				continue
+			}
+			if options.EdgeCodec == nil {
+				gb.AddEdge(from, columns[j])
+				continue
+			}
+			text, err := unquoteValue(adjacencyMatrixFormat, line, text)
+			if err != nil {
+				return nil, err
+			}
+			value, err := options.EdgeCodec.Decode(text)
+			if err != nil {
+				return nil, err
+			}
+			gb.AddEdge(from, columns[j], value)
+		}
+	}
+	return gb.Build()
+}
@@ -0,0 +1,109 @@
+package encoding
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/r0ddy/conquer/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteAdjacencyMatrix_Undirected(t *testing.T) {
+	g := buildTestGraph(t, graph.BuilderOptions{})
+	var buf bytes.Buffer
+	err := WriteAdjacencyMatrix(&buf, g, Options{EdgeCodec: JSONCodec{}})
+	assert.NoError(t, err)
+	expected_matrix := ",1,2,3,4\n" +
+		"1,,1.5,0.25,\n" +
+		"2,1.5,,2,\n" +
+		"3,0.25,2,,\n" +
+		"4,,,,\n"
+	assert.Equal(t, expected_matrix, buf.String())
+
+	actual_graph, err := ReadAdjacencyMatrix(&buf, Options{EdgeCodec: JSONCodec{}})
+	assert.NoError(t, err)
+	expected_summary := summarize(t, g)
+	expected_summary.NodeValues = map[graph.NodeID]interface{}{}
+	assert.Equal(t, expected_summary, summarize(t, actual_graph))
+}
+
+func Test_WriteAdjacencyMatrix_Directed(t *testing.T) {
+	g := buildTestGraph(t, graph.BuilderOptions{IsDirected: true})
+	var buf bytes.Buffer
+	err := WriteAdjacencyMatrix(&buf, g, Options{})
+	assert.NoError(t, err)
+	expected_matrix := ",1,2,3,4\n" +
+		"1,,1,1,\n" +
+		"2,,,,\n" +
+		"3,,1,,\n" +
+		"4,,,,\n"
+	assert.Equal(t, expected_matrix, buf.String())
+
+	actual_graph, err := ReadAdjacencyMatrix(&buf, Options{BuilderOptions: graph.BuilderOptions{IsDirected: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, summarize(t, g).Edges, summarize(t, actual_graph).Edges)
+}
+
+func Test_ReadAdjacencyMatrix(t *testing.T) {
+	matrix := ",1,2\n1,0,1\n2,0,0\n"
+	actual_graph, err := ReadAdjacencyMatrix(strings.NewReader(matrix), Options{BuilderOptions: graph.BuilderOptions{IsDirected: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, []graph.EdgeID{{From: 1, To: 2}}, summarize(t, actual_graph).Edges)
+
+	_, err = ReadAdjacencyMatrix(strings.NewReader(",1,2\n1,,\n"), Options{})
+	assert.EqualError(t, err, "invalid adjacency matrix on line 2: expected 2 rows but found 1")
+	_, err = ReadAdjacencyMatrix(strings.NewReader(",1,2\n2,,\n1,,\n"), Options{})
+	assert.EqualError(t, err, "invalid adjacency matrix on line 2: expected row for node 1 but found 2")
+	_, err = ReadAdjacencyMatrix(strings.NewReader(",1,b\n1,,\nb,,\n"), Options{})
+	assert.EqualError(t, err, "invalid adjacency matrix on line 1: node id \"b\" is not an integer")
+}
+
+func Test_WriteAdjacencyMatrix_ParallelEdges(t *testing.T) {
+	gb := graph.NewGraphBuilder(graph.BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	gb.AddEdge(1, 2)
+	g, err := gb.Build()
+	assert.NoError(t, err)
+	err = WriteAdjacencyMatrix(&bytes.Buffer{}, g, Options{})
+	assert.ErrorIs(t, err, ParallelEdgesError{Format: adjacencyMatrixFormat, FromID: 1, ToID: 2})
+}
+
+func Test_WriteAdjacencyMatrix_MissingValue(t *testing.T) {
+	gb := graph.NewGraphBuilder()
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(2, 3)
+	g, err := gb.Build()
+	assert.NoError(t, err)
+	err = WriteAdjacencyMatrix(&bytes.Buffer{}, g, Options{EdgeCodec: StringCodec{}})
+	assert.ErrorIs(t, err, MissingEdgeValueError{Format: adjacencyMatrixFormat, FromID: 2, ToID: 3})
+
+	var buf bytes.Buffer
+	err = WriteAdjacencyMatrix(&buf, g, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, ",1,2,3\n1,,1,\n2,1,,1\n3,,1,\n", buf.String())
+}
+
+func Test_WriteAdjacencyMatrix_QuotedValues(t *testing.T) {
+	gb := graph.NewGraphBuilder(graph.BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2, "")
+	gb.AddEdge(2, 3, `"quoted"`)
+	gb.AddEdge(3, 1, " padded ")
+	g, err := gb.Build()
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	err = WriteAdjacencyMatrix(&buf, g, Options{EdgeCodec: StringCodec{}})
+	assert.NoError(t, err)
+
+	actual_graph, err := ReadAdjacencyMatrix(&buf, Options{EdgeCodec: StringCodec{}, BuilderOptions: graph.BuilderOptions{IsDirected: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, summarize(t, g), summarize(t, actual_graph))
+}
@@ -0,0 +1,119 @@
+package encoding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/r0ddy/conquer/graph"
+)
+
+// ValueCodec converts node and edge values to and from text.
+type ValueCodec interface {
+	Encode(value interface{}) (string, error)
+	Decode(text string) (interface{}, error)
+}
+
+// StringCodec writes values with fmt.Sprint and reads them back as strings.
+type StringCodec struct{}
+
+func (StringCodec) Encode(value interface{}) (string, error) {
+	return fmt.Sprint(value), nil
+}
+
+func (StringCodec) Decode(text string) (interface{}, error) {
+	return text, nil
+}
+
+// JSONCodec writes values as JSON. Values are read back into the types chosen by encoding/json
+// unless New is set, in which case they are decoded into the value it returns a pointer to.
+type JSONCodec struct {
+	New func() interface{}
+}
+
+func (c JSONCodec) Encode(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+func (c JSONCodec) Decode(text string) (interface{}, error) {
+	if c.New == nil {
+		var value interface{}
+		err := json.Unmarshal([]byte(text), &value)
+		return value, err
+	}
+	target := c.New()
+	if err := json.Unmarshal([]byte(text), target); err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(target).Elem().Interface(), nil
+}
+
+// Options configures the readers and writers of this package.
+type Options struct {
+	// NodeCodec converts node values. If nil, node values are not written or read.
+	NodeCodec ValueCodec
+	// EdgeCodec converts edge values. If nil, edge values are not written or read.
+	EdgeCodec ValueCodec
+	// BuilderOptions are passed to the graph builder when reading.
+	// Formats that store whether the graph is directed override IsDirected.
+	BuilderOptions graph.BuilderOptions
+}
+
+// encodeNodeValue returns the text of a node's value and whether it has one.
+func encodeNodeValue(codec ValueCodec, node graph.Node) (string, bool, error) {
+	if codec == nil {
+		return "", false, nil
+	}
+	value, err := node.GetValue()
+	if errors.As(err, &graph.NoValueFoundInNodeError{}) {
+		// a node without a value is written without one
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	text, err := codec.Encode(value)
+	return text, true, err
+}
+
+// encodeEdgeValue returns the text of an edge's value and whether it has one.
+func encodeEdgeValue(codec ValueCodec, edge graph.Edge) (string, bool, error) {
+	if codec == nil {
+		return "", false, nil
+	}
+	value, err := edge.GetValue()
+	if errors.As(err, &graph.NoValueFoundInEdgeError{}) {
+		// an edge without a value is written without one
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	text, err := codec.Encode(value)
+	return text, true, err
+}
+
+// quoteValue quotes text with strconv.Quote if needsQuotes is true or text starts with a quote,
+// so that quoted text can always be told apart from text written as is.
+func quoteValue(text string, needsQuotes bool) string {
+	if needsQuotes || strings.HasPrefix(text, `"`) {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// unquoteValue reverses quoteValue for text read on the given line of a file in format.
+func unquoteValue(format string, line int, text string) (string, error) {
+	if !strings.HasPrefix(text, `"`) {
+		return text, nil
+	}
+	value, err := strconv.Unquote(text)
+	if err != nil {
+		return "", SyntaxError{Format: format, Line: line, Message: fmt.Sprintf("value %s is not a valid quoted string", text)}
+	}
+	return value, nil
+}
@@ -0,0 +1,90 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/r0ddy/conquer/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+// graphSummary lists the ids and values of the nodes and edges of a graph, leaving out missing values.
+type graphSummary struct {
+	Directed   bool
+	Nodes      []graph.NodeID
+	NodeValues map[graph.NodeID]interface{}
+	Edges      []graph.EdgeID
+	EdgeValues map[graph.EdgeID]interface{}
+}
+
+func summarize(t *testing.T, g graph.Graph) graphSummary {
+	summary := graphSummary{
+		Directed:   g.IsDirected(),
+		Nodes:      make([]graph.NodeID, 0),
+		NodeValues: make(map[graph.NodeID]interface{}),
+		Edges:      make([]graph.EdgeID, 0),
+		EdgeValues: make(map[graph.EdgeID]interface{}),
+	}
+	nodes, err := g.GetNodes()
+	assert.NoError(t, err)
+	for _, node := range nodes {
+		summary.Nodes = append(summary.Nodes, node.GetID())
+		if value, err := node.GetValue(); err == nil {
+			summary.NodeValues[node.GetID()] = value
+		}
+	}
+	edges, err := g.GetEdges()
+	assert.NoError(t, err)
+	for _, edge := range edges {
+		summary.Edges = append(summary.Edges, edge.GetID())
+		if value, err := edge.GetValue(); err == nil {
+			summary.EdgeValues[edge.GetID()] = value
+		}
+	}
+	return summary
+}
+
+// buildTestGraph builds a graph with string node values, float edge values and the lone node 4.
+func buildTestGraph(t *testing.T, bo graph.BuilderOptions) graph.Graph {
+	gb := graph.NewGraphBuilder(bo)
+	gb.AddNode(1, "north")
+	gb.AddNode(2, "south east")
+	gb.AddNode(3, "west")
+	gb.AddNode(4, "island")
+	gb.AddEdge(1, 2, 1.5)
+	gb.AddEdge(3, 2, 2.0)
+	gb.AddEdge(1, 3, 0.25)
+	g, err := gb.Build()
+	assert.NoError(t, err)
+	return g
+}
+
+func Test_StringCodec(t *testing.T) {
+	codec := StringCodec{}
+	text, err := codec.Encode(12)
+	assert.NoError(t, err)
+	assert.Equal(t, "12", text)
+	value, err := codec.Decode("12")
+	assert.NoError(t, err)
+	assert.Equal(t, "12", value)
+}
+
+func Test_JSONCodec(t *testing.T) {
+	type territory struct {
+		Name string
+		Army int
+	}
+	codec := JSONCodec{}
+	text, err := codec.Encode(territory{Name: "north", Army: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Name":"north","Army":3}`, text)
+	value, err := codec.Decode(text)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "north", "Army": 3.0}, value)
+
+	codec = JSONCodec{New: func() interface{} { return &territory{} }}
+	value, err = codec.Decode(text)
+	assert.NoError(t, err)
+	assert.Equal(t, territory{Name: "north", Army: 3}, value)
+	_, err = codec.Decode("{")
+	assert.Error(t, err)
+}
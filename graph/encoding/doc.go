@@ -0,0 +1,7 @@
+// Package encoding reads and writes graphs in plain text formats used by other graph tools:
+// edge lists, CSV adjacency matrices and GraphML.
+//
+// Node and edge values are written with a ValueCodec. Without a codec, values are left out when writing
+// and nodes and edges are built without values when reading.
+// Readers build graphs with graph.NewGraphBuilder, so the builder options decide which graphs are accepted.
+package encoding
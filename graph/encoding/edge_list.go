@@ -0,0 +1,135 @@
+package encoding
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/r0ddy/conquer/graph"
+)
+
+const edgeListFormat = "edge list"
+
+// WriteEdgeList writes one line per edge sorted by id with the ids of its nodes and its encoded value,
+// separated by spaces. Nodes without edges are written as a line with just their id.
+// Node values are not written. Edge values must not span multiple lines.
+// Values that are empty, start or end with a space or start with a quote are written quoted like Go strings.
+func WriteEdgeList(w io.Writer, g graph.Graph, options Options) error {
+	bw := bufio.NewWriter(w)
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		incident, err := node.GetIncidentEdges()
+		if err != nil {
+			return err
+		}
+		if len(incident) == 0 {
+			fmt.Fprintf(bw, "%d\n", node.GetID())
+		}
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		fmt.Fprintf(bw, "%d %d", edge.GetID().From, edge.GetID().To)
+		text, hasValue, err := encodeEdgeValue(options.EdgeCodec, edge)
+		if err != nil {
+			return err
+		}
+		if hasValue {
+			if strings.ContainsAny(text, "\r\n") {
+				return MultilineValueError{Format: edgeListFormat, Value: text}
+			}
+			fmt.Fprintf(bw, " %s", quoteValue(text, text == "" || strings.TrimSpace(text) != text))
+		}
+		fmt.Fprint(bw, "\n")
+	}
+	return bw.Flush()
+}
+
+// ReadEdgeList reads a graph written by WriteEdgeList. Blank lines and lines starting with # are skipped.
+// A line with two ids adds an edge and the rest of the line, if any, is decoded as its value
+// after unquoting it if it starts with a quote.
+// A line with a single id adds a node, and nodes only mentioned in edges are added without a value.
+func ReadEdgeList(r io.Reader, options Options) (graph.Graph, error) {
+	nodeOrder := make([]graph.NodeID, 0)
+	seen := make(map[graph.NodeID]bool)
+	addNode := func(id graph.NodeID) {
+		if !seen[id] {
+			seen[id] = true
+			nodeOrder = append(nodeOrder, id)
+		}
+	}
+	type listedEdge struct {
+		from, to graph.NodeID
+		value    string
+		hasValue bool
+	}
+	edges := make([]listedEdge, 0)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		ids := make([]graph.NodeID, 0, 2)
+		rest := text
+		for len(ids) < 2 && rest != "" {
+			var field string
+			field, rest = cutField(rest)
+			id, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, SyntaxError{Format: edgeListFormat, Line: line, Message: fmt.Sprintf("node id %q is not an integer", field)}
+			}
+			ids = append(ids, graph.NodeID(id))
+			addNode(graph.NodeID(id))
+		}
+		if len(ids) == 2 {
+			edge := listedEdge{from: ids[0], to: ids[1]}
+			if rest != "" && options.EdgeCodec != nil {
+				value, err := unquoteValue(edgeListFormat, line, rest)
+				if err != nil {
+					return nil, err
+				}
+				edge.value, edge.hasValue = value, true
+			}
+			edges = append(edges, edge)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	gb := graph.NewGraphBuilder(options.BuilderOptions)
+	for _, id := range nodeOrder {
+		gb.AddNode(id)
+	}
+	for _, edge := range edges {
+		if !edge.hasValue {
+			gb.AddEdge(edge.from, edge.to)
+			continue
+		}
+		value, err := options.EdgeCodec.Decode(edge.value)
+		if err != nil {
+			return nil, err
+		}
+		gb.AddEdge(edge.from, edge.to, value)
+	}
+	return gb.Build()
+}
+
+// cutField splits s at its first run of spaces and trims the spaces from the rest.
+func cutField(s string) (string, string) {
+	end := strings.IndexFunc(s, unicode.IsSpace)
+	if end < 0 {
+		return s, ""
+	}
+	return s[:end], strings.TrimLeftFunc(s[end:], unicode.IsSpace)
+}
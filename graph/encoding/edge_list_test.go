@@ -0,0 +1,77 @@
+package encoding
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/r0ddy/conquer/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteEdgeList(t *testing.T) {
+	g := buildTestGraph(t, graph.BuilderOptions{IsDirected: true})
+	var buf bytes.Buffer
+	err := WriteEdgeList(&buf, g, Options{EdgeCodec: JSONCodec{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "4\n1 2 1.5\n1 3 0.25\n3 2 2\n", buf.String())
+
+	actual_graph, err := ReadEdgeList(&buf, Options{EdgeCodec: JSONCodec{}, BuilderOptions: graph.BuilderOptions{IsDirected: true}})
+	assert.NoError(t, err)
+	expected_summary := summarize(t, g)
+	expected_summary.NodeValues = map[graph.NodeID]interface{}{}
+	assert.Equal(t, expected_summary, summarize(t, actual_graph))
+}
+
+func Test_ReadEdgeList(t *testing.T) {
+	list := "# roads\n\n1  2   dirt road\n2 3\n5\n"
+	actual_graph, err := ReadEdgeList(strings.NewReader(list), Options{EdgeCodec: StringCodec{}})
+	assert.NoError(t, err)
+	actual_summary := summarize(t, actual_graph)
+	assert.False(t, actual_summary.Directed)
+	assert.Equal(t, []graph.NodeID{1, 2, 3, 5}, actual_summary.Nodes)
+	assert.Equal(t, []graph.EdgeID{{From: 1, To: 2}, {From: 2, To: 3}}, actual_summary.Edges)
+	assert.Equal(t, map[graph.EdgeID]interface{}{{From: 1, To: 2}: "dirt road"}, actual_summary.EdgeValues)
+
+	actual_graph, err = ReadEdgeList(strings.NewReader(list), Options{})
+	assert.NoError(t, err)
+	assert.Empty(t, summarize(t, actual_graph).EdgeValues)
+
+	_, err = ReadEdgeList(strings.NewReader("1 2\nx 3\n"), Options{})
+	assert.EqualError(t, err, "invalid edge list on line 2: node id \"x\" is not an integer")
+}
+
+func Test_WriteEdgeList_Multiline(t *testing.T) {
+	gb := graph.NewGraphBuilder()
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "a\nb")
+	g, err := gb.Build()
+	assert.NoError(t, err)
+	err = WriteEdgeList(&bytes.Buffer{}, g, Options{EdgeCodec: StringCodec{}})
+	assert.ErrorIs(t, err, MultilineValueError{Format: edgeListFormat, Value: "a\nb"})
+}
+
+func Test_WriteEdgeList_QuotedValues(t *testing.T) {
+	gb := graph.NewGraphBuilder(graph.BuilderOptions{IsDirected: true})
+	for _, id := range []graph.NodeID{1, 2, 3, 4, 5} {
+		gb.AddNode(id)
+	}
+	gb.AddEdge(1, 2, " padded ")
+	gb.AddEdge(2, 3, "")
+	gb.AddEdge(3, 4, `"quoted"`)
+	gb.AddEdge(4, 5, "plain text")
+	g, err := gb.Build()
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	err = WriteEdgeList(&buf, g, Options{EdgeCodec: StringCodec{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "1 2 \" padded \"\n2 3 \"\"\n3 4 \"\\\"quoted\\\"\"\n4 5 plain text\n", buf.String())
+
+	actual_graph, err := ReadEdgeList(&buf, Options{EdgeCodec: StringCodec{}, BuilderOptions: graph.BuilderOptions{IsDirected: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, summarize(t, g), summarize(t, actual_graph))
+
+	_, err = ReadEdgeList(strings.NewReader("1 2 \"open\n"), Options{EdgeCodec: StringCodec{}})
+	assert.EqualError(t, err, "invalid edge list on line 1: value \"open is not a valid quoted string")
+}
@@ -0,0 +1,55 @@
+package encoding
+
+import (
+	"fmt"
+
+	"github.com/r0ddy/conquer/graph"
+)
+
+// SyntaxError is returned when a codec cannot parse its input.
+// Line is 0 if the position of the error is not known.
+type SyntaxError struct {
+	Format  string
+	Line    int
+	Message string
+}
+
+func (e SyntaxError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("invalid %s: %s", e.Format, e.Message)
+	}
+	return fmt.Sprintf("invalid %s on line %d: %s", e.Format, e.Line, e.Message)
+}
+
+// ParallelEdgesError is returned when a graph with parallel edges is written in a format that cannot hold them.
+type ParallelEdgesError struct {
+	Format string
+	FromID graph.NodeID
+	ToID   graph.NodeID
+}
+
+func (e ParallelEdgesError) Error() string {
+	return fmt.Sprintf("cannot write parallel edges from %d to %d as %s", e.FromID, e.ToID, e.Format)
+}
+
+// MissingEdgeValueError is returned when an edge without a value is written with an edge codec
+// in a format that cannot tell edges without values apart from edges with them.
+type MissingEdgeValueError struct {
+	Format string
+	FromID graph.NodeID
+	ToID   graph.NodeID
+}
+
+func (e MissingEdgeValueError) Error() string {
+	return fmt.Sprintf("cannot write edge from %d to %d without a value as %s with an edge codec", e.FromID, e.ToID, e.Format)
+}
+
+// MultilineValueError is returned when a value spanning multiple lines is written in a line based format.
+type MultilineValueError struct {
+	Format string
+	Value  string
+}
+
+func (e MultilineValueError) Error() string {
+	return fmt.Sprintf("cannot write value %q spanning multiple lines as %s", e.Value, e.Format)
+}
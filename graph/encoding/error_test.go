@@ -0,0 +1,29 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SyntaxError(t *testing.T) {
+	actual_error := SyntaxError{Format: "edge list", Line: 2, Message: "node id \"a\" is not an integer"}
+	assert.EqualError(t, actual_error, "invalid edge list on line 2: node id \"a\" is not an integer")
+	actual_error = SyntaxError{Format: "GraphML", Message: "missing graph element"}
+	assert.EqualError(t, actual_error, "invalid GraphML: missing graph element")
+}
+
+func Test_ParallelEdgesError(t *testing.T) {
+	actual_error := ParallelEdgesError{Format: "adjacency matrix", FromID: 1, ToID: 2}
+	assert.EqualError(t, actual_error, "cannot write parallel edges from 1 to 2 as adjacency matrix")
+}
+
+func Test_MissingEdgeValueError(t *testing.T) {
+	actual_error := MissingEdgeValueError{Format: "adjacency matrix", FromID: 1, ToID: 2}
+	assert.EqualError(t, actual_error, "cannot write edge from 1 to 2 without a value as adjacency matrix with an edge codec")
+}
+
+func Test_MultilineValueError(t *testing.T) {
+	actual_error := MultilineValueError{Format: "edge list", Value: "a\nb"}
+	assert.EqualError(t, actual_error, "cannot write value \"a\\nb\" spanning multiple lines as edge list")
+}
@@ -0,0 +1,200 @@
+package encoding
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/r0ddy/conquer/graph"
+)
+
+const graphMLFormat = "GraphML"
+
+const (
+	graphMLNamespace = "http://graphml.graphdrawing.org/xmlns"
+	nodeValueKey     = "value"
+	edgeValueKey     = "edge_value"
+)
+
+type graphMLDocument struct {
+	XMLName xml.Name       `xml:"graphml"`
+	Xmlns   string         `xml:"xmlns,attr,omitempty"`
+	Keys    []graphMLKey   `xml:"key"`
+	Graphs  []graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr,omitempty"`
+	AttrType string `xml:"attr.type,attr,omitempty"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr,omitempty"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func graphMLNodeID(id graph.NodeID) string {
+	return fmt.Sprintf("n%d", id)
+}
+
+// parseGraphMLNodeID accepts both the n<id> ids written by WriteGraphML and plain integer ids.
+func parseGraphMLNodeID(text string) (graph.NodeID, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(text, "n"))
+	if err != nil {
+		return 0, SyntaxError{Format: graphMLFormat, Line: 0, Message: fmt.Sprintf("node id %q is not an integer", text)}
+	}
+	return graph.NodeID(id), nil
+}
+
+// WriteGraphML writes g as a GraphML document with a single graph.
+// Node ids are written as n<id> and values are stored in string data keys named "value" and "edge_value".
+func WriteGraphML(w io.Writer, g graph.Graph, options Options) error {
+	document := graphMLDocument{Xmlns: graphMLNamespace, Keys: make([]graphMLKey, 0)}
+	if options.NodeCodec != nil {
+		document.Keys = append(document.Keys, graphMLKey{ID: nodeValueKey, For: "node", AttrName: nodeValueKey, AttrType: "string"})
+	}
+	if options.EdgeCodec != nil {
+		document.Keys = append(document.Keys, graphMLKey{ID: edgeValueKey, For: "edge", AttrName: edgeValueKey, AttrType: "string"})
+	}
+	encoded := graphMLGraph{ID: "G", EdgeDefault: "undirected"}
+	if g.IsDirected() {
+		encoded.EdgeDefault = "directed"
+	}
+
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		encodedNode := graphMLNode{ID: graphMLNodeID(node.GetID())}
+		text, hasValue, err := encodeNodeValue(options.NodeCodec, node)
+		if err != nil {
+			return err
+		}
+		if hasValue {
+			encodedNode.Data = append(encodedNode.Data, graphMLData{Key: nodeValueKey, Value: text})
+		}
+		encoded.Nodes = append(encoded.Nodes, encodedNode)
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		encodedEdge := graphMLEdge{Source: graphMLNodeID(edge.GetID().From), Target: graphMLNodeID(edge.GetID().To)}
+		text, hasValue, err := encodeEdgeValue(options.EdgeCodec, edge)
+		if err != nil {
+			return err
+		}
+		if hasValue {
+			encodedEdge.Data = append(encodedEdge.Data, graphMLData{Key: edgeValueKey, Value: text})
+		}
+		encoded.Edges = append(encoded.Edges, encodedEdge)
+	}
+	document.Graphs = []graphMLGraph{encoded}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// ReadGraphML reads the first graph of a GraphML document. Whether it is directed is taken from its edgedefault.
+// Node and edge values are read from the data keys whose attr.name is "value" or "edge_value"
+// and all other data is ignored.
+func ReadGraphML(r io.Reader, options Options) (graph.Graph, error) {
+	var document graphMLDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, err
+	}
+	if len(document.Graphs) == 0 {
+		return nil, SyntaxError{Format: graphMLFormat, Line: 0, Message: "missing graph element"}
+	}
+	nodeKeys, edgeKeys := make(map[string]bool), make(map[string]bool)
+	for _, key := range document.Keys {
+		if key.AttrName == nodeValueKey && (key.For == "node" || key.For == "all") {
+			nodeKeys[key.ID] = true
+		}
+		if key.AttrName == edgeValueKey && (key.For == "edge" || key.For == "all") {
+			edgeKeys[key.ID] = true
+		}
+	}
+	findValue := func(data []graphMLData, keys map[string]bool) (string, bool) {
+		for _, d := range data {
+			if keys[d.Key] {
+				return d.Value, true
+			}
+		}
+		return "", false
+	}
+
+	decoded := document.Graphs[0]
+	builderOptions := options.BuilderOptions
+	builderOptions.IsDirected = decoded.EdgeDefault == "directed"
+	gb := graph.NewGraphBuilder(builderOptions)
+	for _, node := range decoded.Nodes {
+		id, err := parseGraphMLNodeID(node.ID)
+		if err != nil {
+			return nil, err
+		}
+		text, hasValue := findValue(node.Data, nodeKeys)
+		if !hasValue || options.NodeCodec == nil {
+			gb.AddNode(id)
+			continue
+		}
+		value, err := options.NodeCodec.Decode(text)
+		if err != nil {
+			return nil, err
+		}
+		gb.AddNode(id, value)
+	}
+	for _, edge := range decoded.Edges {
+		from, err := parseGraphMLNodeID(edge.Source)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseGraphMLNodeID(edge.Target)
+		if err != nil {
+			return nil, err
+		}
+		text, hasValue := findValue(edge.Data, edgeKeys)
+		if !hasValue || options.EdgeCodec == nil {
+			gb.AddEdge(from, to)
+			continue
+		}
+		value, err := options.EdgeCodec.Decode(text)
+		if err != nil {
+			return nil, err
+		}
+		gb.AddEdge(from, to, value)
+	}
+	return gb.Build()
+}
@@ -0,0 +1,65 @@
+package encoding
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/r0ddy/conquer/graph"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteGraphML(t *testing.T) {
+	g := buildTestGraph(t, graph.BuilderOptions{IsDirected: true})
+	var buf bytes.Buffer
+	options := Options{NodeCodec: StringCodec{}, EdgeCodec: JSONCodec{}}
+	err := WriteGraphML(&buf, g, options)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `<graph id="G" edgedefault="directed">`)
+	assert.Contains(t, buf.String(), `<node id="n2">`)
+	assert.Contains(t, buf.String(), `<data key="value">south east</data>`)
+	assert.Contains(t, buf.String(), `<edge source="n1" target="n3">`)
+
+	actual_graph, err := ReadGraphML(&buf, options)
+	assert.NoError(t, err)
+	assert.Equal(t, summarize(t, g), summarize(t, actual_graph))
+}
+
+func Test_WriteGraphML_WithoutValues(t *testing.T) {
+	g := buildTestGraph(t, graph.BuilderOptions{})
+	var buf bytes.Buffer
+	err := WriteGraphML(&buf, g, Options{})
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), "<key")
+	actual_graph, err := ReadGraphML(&buf, Options{NodeCodec: StringCodec{}})
+	assert.NoError(t, err)
+	actual_summary := summarize(t, actual_graph)
+	assert.False(t, actual_summary.Directed)
+	assert.Empty(t, actual_summary.NodeValues)
+	assert.Equal(t, summarize(t, g).Edges, actual_summary.Edges)
+}
+
+func Test_ReadGraphML(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="value" attr.type="string"/>
+  <key id="d1" for="node" attr.name="color" attr.type="string"/>
+  <graph edgedefault="undirected">
+    <node id="1"><data key="d1">red</data><data key="d0">one</data></node>
+    <node id="2"/>
+    <edge source="1" target="2"/>
+  </graph>
+</graphml>`
+	actual_graph, err := ReadGraphML(strings.NewReader(document), Options{NodeCodec: StringCodec{}})
+	assert.NoError(t, err)
+	actual_summary := summarize(t, actual_graph)
+	assert.Equal(t, map[graph.NodeID]interface{}{1: "one"}, actual_summary.NodeValues)
+	assert.Equal(t, []graph.EdgeID{{From: 1, To: 2}}, actual_summary.Edges)
+
+	_, err = ReadGraphML(strings.NewReader(`<graphml><graph><node id="a"/></graph></graphml>`), Options{})
+	assert.EqualError(t, err, "invalid GraphML: node id \"a\" is not an integer")
+	_, err = ReadGraphML(strings.NewReader(`<graphml></graphml>`), Options{})
+	assert.EqualError(t, err, "invalid GraphML: missing graph element")
+	_, err = ReadGraphML(strings.NewReader(`<graphml>`), Options{})
+	assert.Error(t, err)
+}
@@ -1,95 +1,290 @@
 package graph
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
-type duplicateNodeError struct {
-	nodeID NodeID
+// DuplicateNodeError is returned when a node is added twice and duplicate nodes are not allowed.
+type DuplicateNodeError struct {
+	NodeID NodeID
 }
 
-func (e duplicateNodeError) Error() string {
-	return fmt.Sprintf("node with id %d has already been added", e.nodeID)
+func (e DuplicateNodeError) Error() string {
+	return fmt.Sprintf("node with id %d has already been added", e.NodeID)
 }
 
-type duplicateEdgeError struct {
-	fromID NodeID
-	toID   NodeID
+// DuplicateEdgeError is returned when an edge is added twice and neither duplicate nor parallel edges are allowed.
+type DuplicateEdgeError struct {
+	FromID NodeID
+	ToID   NodeID
 }
 
-func (e duplicateEdgeError) Error() string {
-	return fmt.Sprintf("edge from %d to %d has already been added", e.fromID, e.toID)
+func (e DuplicateEdgeError) Error() string {
+	return fmt.Sprintf("edge from %d to %d has already been added", e.FromID, e.ToID)
 }
 
-type redundantEdgeError struct {
-	nodeID NodeID
+// RedundantEdgeError is returned when a self-loop is added and redundant edges are not allowed.
+type RedundantEdgeError struct {
+	NodeID NodeID
 }
 
-func (e redundantEdgeError) Error() string {
-	return fmt.Sprintf("edge from %d to %d is redundant", e.nodeID, e.nodeID)
+func (e RedundantEdgeError) Error() string {
+	return fmt.Sprintf("edge from %d to %d is redundant", e.NodeID, e.NodeID)
 }
 
-type nodeNotFoundError struct {
-	nodeID NodeID
+// NodeNotFoundError is returned when a node id is not in the graph.
+type NodeNotFoundError struct {
+	NodeID NodeID
 }
 
-func (e nodeNotFoundError) Error() string {
-	return fmt.Sprintf("node with id %d could not be found", e.nodeID)
+func (e NodeNotFoundError) Error() string {
+	return fmt.Sprintf("node with id %d could not be found", e.NodeID)
 }
 
-type multipleValuesForNodeError struct {
-	nodeID NodeID
+// MultipleValuesForNodeError is returned when a node is given more than one value.
+type MultipleValuesForNodeError struct {
+	NodeID NodeID
 }
 
-func (e multipleValuesForNodeError) Error() string {
-	return fmt.Sprintf("multiple values provided for node with id %d", e.nodeID)
+func (e MultipleValuesForNodeError) Error() string {
+	return fmt.Sprintf("multiple values provided for node with id %d", e.NodeID)
 }
 
-type multipleValuesForEdgeError struct {
-	fromID NodeID
-	toID   NodeID
+// MultipleValuesForEdgeError is returned when an edge is given more than one value.
+type MultipleValuesForEdgeError struct {
+	FromID NodeID
+	ToID   NodeID
 }
 
-func (e multipleValuesForEdgeError) Error() string {
-	return fmt.Sprintf("multiple values provided for edge from %d to %d", e.fromID, e.toID)
+func (e MultipleValuesForEdgeError) Error() string {
+	return fmt.Sprintf("multiple values provided for edge from %d to %d", e.FromID, e.ToID)
 }
 
-type edgeNotFoundError struct {
-	fromID NodeID
-	toID   NodeID
+// EdgeNotFoundError is returned when there is no edge between two nodes.
+type EdgeNotFoundError struct {
+	FromID NodeID
+	ToID   NodeID
 }
 
-func (e edgeNotFoundError) Error() string {
-	return fmt.Sprintf("edge from %d to %d could not be found", e.fromID, e.toID)
+func (e EdgeNotFoundError) Error() string {
+	return fmt.Sprintf("edge from %d to %d could not be found", e.FromID, e.ToID)
 }
 
-type noValueFoundInNodeError struct {
-	nodeID NodeID
+// NoValueFoundInNodeError is returned when the value of a node that has none is requested.
+type NoValueFoundInNodeError struct {
+	NodeID NodeID
 }
 
-func (e noValueFoundInNodeError) Error() string {
-	return fmt.Sprintf("no value found in node with id %d", e.nodeID)
+func (e NoValueFoundInNodeError) Error() string {
+	return fmt.Sprintf("no value found in node with id %d", e.NodeID)
 }
 
-type noValueFoundInEdgeError struct {
-	fromID NodeID
-	toID   NodeID
+// NoValueFoundInEdgeError is returned when the value of an edge that has none is requested.
+type NoValueFoundInEdgeError struct {
+	FromID NodeID
+	ToID   NodeID
 }
 
-func (e noValueFoundInEdgeError) Error() string {
-	return fmt.Sprintf("no value found in edge from %d to %d", e.fromID, e.toID)
+func (e NoValueFoundInEdgeError) Error() string {
+	return fmt.Sprintf("no value found in edge from %d to %d", e.FromID, e.ToID)
 }
 
-type cannotUseForDirectedGraphError struct {
-	methodName string
+// CannotUseForDirectedGraphError is returned when a method only works on undirected graphs.
+type CannotUseForDirectedGraphError struct {
+	MethodName string
 }
 
-func (e cannotUseForDirectedGraphError) Error() string {
-	return fmt.Sprintf("cannot use %s on directed graph", e.methodName)
+func (e CannotUseForDirectedGraphError) Error() string {
+	return fmt.Sprintf("cannot use %s on directed graph", e.MethodName)
 }
 
-type cannotUseForUndirectedGraphError struct {
-	methodName string
+// CannotUseForUndirectedGraphError is returned when a method only works on directed graphs.
+type CannotUseForUndirectedGraphError struct {
+	MethodName string
 }
 
-func (e cannotUseForUndirectedGraphError) Error() string {
-	return fmt.Sprintf("cannot use %s on undirected graph", e.methodName)
+func (e CannotUseForUndirectedGraphError) Error() string {
+	return fmt.Sprintf("cannot use %s on undirected graph", e.MethodName)
+}
+
+// NoPathFoundError is returned when a node cannot be reached from another.
+type NoPathFoundError struct {
+	FromID NodeID
+	ToID   NodeID
+}
+
+func (e NoPathFoundError) Error() string {
+	return fmt.Sprintf("no path found from %d to %d", e.FromID, e.ToID)
+}
+
+// NegativeWeightError is returned when an algorithm that needs non-negative weights finds a negative one.
+type NegativeWeightError struct {
+	FromID NodeID
+	ToID   NodeID
+}
+
+func (e NegativeWeightError) Error() string {
+	return fmt.Sprintf("edge from %d to %d has a negative weight", e.FromID, e.ToID)
+}
+
+// InvalidWeightError is returned when an algorithm that needs finite weights finds a weight that is NaN or infinite.
+type InvalidWeightError struct {
+	FromID NodeID
+	ToID   NodeID
+	Weight float64
+}
+
+func (e InvalidWeightError) Error() string {
+	return fmt.Sprintf("edge from %d to %d has an invalid weight %v", e.FromID, e.ToID, e.Weight)
+}
+
+// CycleError is returned when an algorithm that needs a directed acyclic graph finds a cycle.
+type CycleError struct {
+	// NodeIDs are the nodes of the cycle in the direction of its edges, starting with the smallest id.
+	// The edge from the last node back to the first closes the cycle.
+	NodeIDs []NodeID
+}
+
+func (e CycleError) Error() string {
+	cycle := ""
+	for _, id := range e.NodeIDs {
+		cycle += fmt.Sprintf("%d -> ", id)
+	}
+	if len(e.NodeIDs) > 0 {
+		cycle += fmt.Sprintf("%d", e.NodeIDs[0])
+	}
+	return fmt.Sprintf("graph has a cycle %s", cycle)
+}
+
+// NegativeMaxDepthError is returned when a search is asked to stop at a negative depth.
+type NegativeMaxDepthError struct {
+	MaxDepth int
+}
+
+func (e NegativeMaxDepthError) Error() string {
+	return fmt.Sprintf("max depth %d is negative", e.MaxDepth)
+}
+
+// NegativeCapacityError is returned when an edge has a negative capacity.
+type NegativeCapacityError struct {
+	FromID NodeID
+	ToID   NodeID
+}
+
+func (e NegativeCapacityError) Error() string {
+	return fmt.Sprintf("edge from %d to %d has a negative capacity", e.FromID, e.ToID)
+}
+
+// InvalidCapacityError is returned when an edge has a capacity that is NaN or infinite.
+type InvalidCapacityError struct {
+	FromID   NodeID
+	ToID     NodeID
+	Capacity float64
+}
+
+func (e InvalidCapacityError) Error() string {
+	return fmt.Sprintf("edge from %d to %d has an invalid capacity %v", e.FromID, e.ToID, e.Capacity)
+}
+
+// SourceIsSinkError is returned when a flow is requested from a node to itself.
+type SourceIsSinkError struct {
+	NodeID NodeID
+}
+
+func (e SourceIsSinkError) Error() string {
+	return fmt.Sprintf("node with id %d cannot be both the source and the sink", e.NodeID)
+}
+
+// OddCycleError is returned when a graph is not bipartite.
+type OddCycleError struct {
+	// NodeIDs are the nodes of a cycle with an odd number of nodes in the order they are connected,
+	// starting with the smallest id. The edge from the last node back to the first closes the cycle.
+	NodeIDs []NodeID
+}
+
+func (e OddCycleError) Error() string {
+	cycle := ""
+	for _, id := range e.NodeIDs {
+		cycle += fmt.Sprintf("%d - ", id)
+	}
+	if len(e.NodeIDs) > 0 {
+		cycle += fmt.Sprintf("%d", e.NodeIDs[0])
+	}
+	return fmt.Sprintf("graph is not bipartite because of the odd cycle %s", cycle)
+}
+
+// DOTSyntaxError is returned when ReadDOT cannot parse its input.
+type DOTSyntaxError struct {
+	Line    int
+	Message string
+}
+
+func (e DOTSyntaxError) Error() string {
+	return fmt.Sprintf("invalid DOT on line %d: %s", e.Line, e.Message)
+}
+
+// KeyNotFoundError is returned when a key was never added to a keyed graph.
+type KeyNotFoundError[K comparable] struct {
+	Key K
+}
+
+func (e KeyNotFoundError[K]) Error() string {
+	return fmt.Sprintf("node with key %v could not be found", e.Key)
+}
+
+// ErrMixedDirectedness is returned when a directed graph is combined with an undirected graph.
+var ErrMixedDirectedness = errors.New("cannot combine a directed graph with an undirected graph")
+
+// ErrNotIsomorphic is returned when two graphs are not isomorphic.
+var ErrNotIsomorphic = errors.New("graphs are not isomorphic")
+
+// CallError is an invalid AddNode or AddEdge call made to a builder.
+type CallError struct {
+	// Index is the position of the call among all the AddNode and AddEdge calls made to the builder, starting at 0.
+	Index int
+	// Err is the error the call caused, such as a DuplicateNodeError.
+	Err error
+}
+
+func (e CallError) Error() string {
+	return fmt.Sprintf("call %d: %s", e.Index, e.Err)
+}
+
+func (e CallError) Unwrap() error {
+	return e.Err
+}
+
+// BuilderError is returned by Build when BuilderOptions.CollectAllErrors is set and some calls were invalid.
+// errors.Is and errors.As look through every call, so errors.As(err, &NodeNotFoundError{}) finds the first one.
+type BuilderError struct {
+	// Calls are the invalid calls sorted by index.
+	Calls []CallError
+}
+
+func (e BuilderError) Error() string {
+	calls := make([]string, 0, len(e.Calls))
+	for _, call := range e.Calls {
+		calls = append(calls, call.Error())
+	}
+	return fmt.Sprintf("%d invalid builder calls: %s", len(e.Calls), strings.Join(calls, "; "))
+}
+
+func (e BuilderError) Is(target error) bool {
+	for _, call := range e.Calls {
+		if errors.Is(call, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e BuilderError) As(target interface{}) bool {
+	for _, call := range e.Calls {
+		if errors.As(call, target) {
+			return true
+		}
+	}
+	return false
 }
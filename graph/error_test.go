@@ -1,62 +1,147 @@
 package graph
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func Test_DuplicateNodeError(t *testing.T) {
-	actual_error := duplicateNodeError{nodeID: 1}
+	actual_error := DuplicateNodeError{NodeID: 1}
 	assert.EqualError(t, actual_error, "node with id 1 has already been added")
 }
 
 func Test_DuplicateEdgeError(t *testing.T) {
-	actual_error := duplicateEdgeError{fromID: 1, toID: 2}
+	actual_error := DuplicateEdgeError{FromID: 1, ToID: 2}
 	assert.EqualError(t, actual_error, "edge from 1 to 2 has already been added")
 }
 
 func Test_RedundantEdgeError(t *testing.T) {
-	actual_error := redundantEdgeError{nodeID: 1}
+	actual_error := RedundantEdgeError{NodeID: 1}
 	assert.EqualError(t, actual_error, "edge from 1 to 1 is redundant")
 }
 
 func Test_NodeNotFoundError(t *testing.T) {
-	actual_error := nodeNotFoundError{nodeID: 1}
+	actual_error := NodeNotFoundError{NodeID: 1}
 	assert.EqualError(t, actual_error, "node with id 1 could not be found")
 }
 
 func Test_MultipleValuesForNodeError(t *testing.T) {
-	actual_error := multipleValuesForNodeError{nodeID: 1}
+	actual_error := MultipleValuesForNodeError{NodeID: 1}
 	assert.EqualError(t, actual_error, "multiple values provided for node with id 1")
 }
 
 func Test_MultipleValuesForEdgeError(t *testing.T) {
-	actual_error := multipleValuesForEdgeError{fromID: 1, toID: 2}
+	actual_error := MultipleValuesForEdgeError{FromID: 1, ToID: 2}
 	assert.EqualError(t, actual_error, "multiple values provided for edge from 1 to 2")
 }
 
 func Test_EdgeNotFoundError(t *testing.T) {
-	actual_error := edgeNotFoundError{fromID: 1, toID: 2}
+	actual_error := EdgeNotFoundError{FromID: 1, ToID: 2}
 	assert.EqualError(t, actual_error, "edge from 1 to 2 could not be found")
 }
 
 func Test_NoValueFoundInNodeError(t *testing.T) {
-	actual_error := noValueFoundInNodeError{nodeID: 1}
+	actual_error := NoValueFoundInNodeError{NodeID: 1}
 	assert.EqualError(t, actual_error, "no value found in node with id 1")
 }
 
 func Test_NoValueFoundInEdgeError(t *testing.T) {
-	actual_error := noValueFoundInEdgeError{fromID: 1, toID: 2}
+	actual_error := NoValueFoundInEdgeError{FromID: 1, ToID: 2}
 	assert.EqualError(t, actual_error, "no value found in edge from 1 to 2")
 }
 
 func Test_CannotUseForDirectedGraphError(t *testing.T) {
-	actual_error := cannotUseForDirectedGraphError{methodName: "Node.GetID"}
+	actual_error := CannotUseForDirectedGraphError{MethodName: "Node.GetID"}
 	assert.EqualError(t, actual_error, "cannot use Node.GetID on directed graph")
 }
 
 func Test_CannotUseForUndirectedGraphError(t *testing.T) {
-	actual_error := cannotUseForUndirectedGraphError{methodName: "Node.GetID"}
+	actual_error := CannotUseForUndirectedGraphError{MethodName: "Node.GetID"}
 	assert.EqualError(t, actual_error, "cannot use Node.GetID on undirected graph")
 }
+
+func Test_NoPathFoundError(t *testing.T) {
+	actual_error := NoPathFoundError{FromID: 1, ToID: 2}
+	assert.EqualError(t, actual_error, "no path found from 1 to 2")
+}
+
+func Test_NegativeWeightError(t *testing.T) {
+	actual_error := NegativeWeightError{FromID: 1, ToID: 2}
+	assert.EqualError(t, actual_error, "edge from 1 to 2 has a negative weight")
+}
+
+func Test_InvalidWeightError(t *testing.T) {
+	actual_error := InvalidWeightError{FromID: 1, ToID: 2, Weight: math.Inf(1)}
+	assert.EqualError(t, actual_error, "edge from 1 to 2 has an invalid weight +Inf")
+}
+
+func Test_CycleError(t *testing.T) {
+	actual_error := CycleError{NodeIDs: []NodeID{1, 2, 3}}
+	assert.EqualError(t, actual_error, "graph has a cycle 1 -> 2 -> 3 -> 1")
+}
+
+func Test_NegativeMaxDepthError(t *testing.T) {
+	actual_error := NegativeMaxDepthError{MaxDepth: -1}
+	assert.EqualError(t, actual_error, "max depth -1 is negative")
+}
+
+func Test_NegativeCapacityError(t *testing.T) {
+	actual_error := NegativeCapacityError{FromID: 1, ToID: 2}
+	assert.EqualError(t, actual_error, "edge from 1 to 2 has a negative capacity")
+}
+
+func Test_InvalidCapacityError(t *testing.T) {
+	actual_error := InvalidCapacityError{FromID: 1, ToID: 2, Capacity: math.NaN()}
+	assert.EqualError(t, actual_error, "edge from 1 to 2 has an invalid capacity NaN")
+}
+
+func Test_SourceIsSinkError(t *testing.T) {
+	actual_error := SourceIsSinkError{NodeID: 1}
+	assert.EqualError(t, actual_error, "node with id 1 cannot be both the source and the sink")
+}
+
+func Test_OddCycleError(t *testing.T) {
+	actual_error := OddCycleError{NodeIDs: []NodeID{1, 2, 3}}
+	assert.EqualError(t, actual_error, "graph is not bipartite because of the odd cycle 1 - 2 - 3 - 1")
+}
+
+func Test_DOTSyntaxError(t *testing.T) {
+	actual_error := DOTSyntaxError{Line: 3, Message: "unterminated string"}
+	assert.EqualError(t, actual_error, "invalid DOT on line 3: unterminated string")
+}
+
+func Test_KeyNotFoundError(t *testing.T) {
+	actual_error := KeyNotFoundError[string]{Key: "Alaska"}
+	assert.EqualError(t, actual_error, "node with key Alaska could not be found")
+}
+
+func Test_MixedDirectednessError(t *testing.T) {
+	actual_error := ErrMixedDirectedness
+	assert.EqualError(t, actual_error, "cannot combine a directed graph with an undirected graph")
+}
+
+func Test_NotIsomorphicError(t *testing.T) {
+	actual_error := ErrNotIsomorphic
+	assert.EqualError(t, actual_error, "graphs are not isomorphic")
+}
+
+func Test_CallError(t *testing.T) {
+	actual_error := CallError{Index: 2, Err: DuplicateNodeError{NodeID: 1}}
+	assert.EqualError(t, actual_error, "call 2: node with id 1 has already been added")
+	assert.ErrorIs(t, actual_error, DuplicateNodeError{NodeID: 1})
+}
+
+func Test_BuilderError(t *testing.T) {
+	actual_error := BuilderError{Calls: []CallError{
+		{Index: 2, Err: DuplicateNodeError{NodeID: 1}},
+		{Index: 5, Err: NodeNotFoundError{NodeID: 3}},
+	}}
+	assert.EqualError(t, actual_error, "2 invalid builder calls: call 2: node with id 1 has already been added; call 5: node with id 3 could not be found")
+	assert.ErrorIs(t, actual_error, NodeNotFoundError{NodeID: 3})
+	assert.NotErrorIs(t, actual_error, NodeNotFoundError{NodeID: 1})
+	var notFound NodeNotFoundError
+	assert.ErrorAs(t, actual_error, &notFound)
+	assert.Equal(t, NodeID(3), notFound.NodeID)
+}
@@ -2,58 +2,86 @@ package graph
 
 import "sort"
 
-// Graph represents a directed/undirected graph.
-type Graph interface {
+// Graph represents a directed/undirected graph whose node and edge values are untyped.
+type Graph = TypedGraph[interface{}, interface{}]
+
+// TypedGraph represents a directed/undirected graph that stores values of type N
+// in its nodes and values of type E in its edges.
+type TypedGraph[N, E any] interface {
 	// GetNode fetches a node by its id.
 	// If the id does not exist in the graph, it returns a node not found error.
-	GetNode(id NodeID) (Node, error)
+	GetNode(id NodeID) (TypedNode[N, E], error)
 
 	// GetEdge fetches an edge by its two endpoint.
 	// In a directed graph, the from parameter takes the id of where the edge starts
 	// while the to parameter takes the id of where the edge ends.
 	// In an undirected graph, GetEdge(a, b) is equivalent to GetEdge(b, a).
 	// If edge from-to does not exist in the graph, it returns an edge not found error.
-	GetEdge(from NodeID, to NodeID) (Edge, error)
+	// If there are parallel edges from-to, it returns the one with the lowest index.
+	GetEdge(from NodeID, to NodeID) (TypedEdge[N, E], error)
+
+	// GetEdgesBetween fetches all the parallel edges from-to sorted by their index (ascending).
+	// It follows the same endpoint rules as GetEdge and returns at least one edge.
+	// If edge from-to does not exist in the graph, it returns an edge not found error.
+	GetEdgesBetween(from NodeID, to NodeID) ([]TypedEdge[N, E], error)
 
 	// GetNodes fetches all the unique nodes of this graph sorted by id (asecnding).
-	GetNodes() ([]Node, error)
+	GetNodes() ([]TypedNode[N, E], error)
 
 	// GetEdges fetches all the unique edges of this graph.
 	// In a directed graph, the edges are sorted by from NodeID then to NodeID (ascending).
 	// In a undirected graph, the nodes in an edge are sorted by id (ascending) and
 	// then the edges are sorted by the first entry in that node slice (also ascending).
 	// If those are equal, then they're sorted by the next entry.
-	GetEdges() ([]Edge, error)
+	// In both cases, parallel edges between the same nodes are sorted by their index (ascending).
+	GetEdges() ([]TypedEdge[N, E], error)
 
 	// IsDirected returns true if the graph is directed and false if its undirected.
 	IsDirected() bool
-	removeRefs() Graph
+	removeRefs() TypedGraph[N, E]
 }
 
-type rawDirectedGraph struct {
-	FromToEdges map[NodeID]map[NodeID]*rawDirectedEdge
-	Nodes       map[NodeID]*rawDirectedNode
+type rawDirectedGraph = directedGraph[interface{}, interface{}]
+
+type directedGraph[N, E any] struct {
+	FromToEdges map[NodeID]map[NodeID]*directedEdge[N, E]
+	Nodes       map[NodeID]*directedNode[N, E]
+	// Parallel maps from-to to the edges after the first one, sorted by index.
+	// It stays nil unless the graph has parallel edges.
+	Parallel map[NodeID]map[NodeID][]*directedEdge[N, E]
 }
 
-func (rg rawDirectedGraph) GetNode(id NodeID) (Node, error) {
+func (rg directedGraph[N, E]) GetNode(id NodeID) (TypedNode[N, E], error) {
 	node, exists := rg.Nodes[id]
 	if !exists {
-		return nil, nodeNotFoundError{nodeID: id}
+		return nil, NodeNotFoundError{NodeID: id}
 	}
 	return node, nil
 }
 
-func (rg rawDirectedGraph) GetEdge(from NodeID, to NodeID) (Edge, error) {
+func (rg directedGraph[N, E]) GetEdge(from NodeID, to NodeID) (TypedEdge[N, E], error) {
 	if _, fromExists := rg.FromToEdges[from]; fromExists {
 		if edge, toExists := rg.FromToEdges[from][to]; toExists {
 			return edge, nil
 		}
 	}
-	return nil, edgeNotFoundError{fromID: from, toID: to}
+	return nil, EdgeNotFoundError{FromID: from, ToID: to}
+}
+
+func (rg directedGraph[N, E]) GetEdgesBetween(from NodeID, to NodeID) ([]TypedEdge[N, E], error) {
+	first, err := rg.GetEdge(from, to)
+	if err != nil {
+		return nil, err
+	}
+	edges := []TypedEdge[N, E]{first}
+	for _, edge := range rg.Parallel[from][to] {
+		edges = append(edges, edge)
+	}
+	return edges, nil
 }
 
-func (rg rawDirectedGraph) GetNodes() ([]Node, error) {
-	nodes := make([]Node, 0)
+func (rg directedGraph[N, E]) GetNodes() ([]TypedNode[N, E], error) {
+	nodes := make([]TypedNode[N, E], 0)
 	for _, node := range rg.Nodes {
 		nodes = append(nodes, node)
 	}
@@ -61,67 +89,129 @@ func (rg rawDirectedGraph) GetNodes() ([]Node, error) {
 	return nodes, nil
 }
 
-func (rg rawDirectedGraph) GetEdges() ([]Edge, error) {
-	directedEdges := make([]*rawDirectedEdge, 0)
+func (rg directedGraph[N, E]) GetEdges() ([]TypedEdge[N, E], error) {
+	directedEdges := make([]*directedEdge[N, E], 0)
 	for _, subEdges := range rg.FromToEdges {
 		for _, edge := range subEdges {
 			directedEdges = append(directedEdges, edge)
 		}
 	}
+	for _, subEdges := range rg.Parallel {
+		for _, parallelEdges := range subEdges {
+			directedEdges = append(directedEdges, parallelEdges...)
+		}
+	}
 	sort.Slice(directedEdges, func(i, j int) bool {
 		if directedEdges[i].From != directedEdges[j].From {
 			return directedEdges[i].From < directedEdges[j].From
 		}
-		return directedEdges[i].To < directedEdges[j].To
+		if directedEdges[i].To != directedEdges[j].To {
+			return directedEdges[i].To < directedEdges[j].To
+		}
+		return directedEdges[i].Index < directedEdges[j].Index
 	})
-	edges := make([]Edge, 0)
+	edges := make([]TypedEdge[N, E], 0)
 	for _, directedEdge := range directedEdges {
 		edges = append(edges, directedEdge)
 	}
 	return edges, nil
 }
 
-func (g rawDirectedGraph) IsDirected() bool {
+func (rg *directedGraph[N, E]) addParallelEdge(edge *directedEdge[N, E]) {
+	if rg.Parallel == nil {
+		rg.Parallel = make(map[NodeID]map[NodeID][]*directedEdge[N, E])
+	}
+	if _, fromExists := rg.Parallel[edge.From]; !fromExists {
+		rg.Parallel[edge.From] = make(map[NodeID][]*directedEdge[N, E])
+	}
+	rg.Parallel[edge.From][edge.To] = append(rg.Parallel[edge.From][edge.To], edge)
+}
+
+func (g directedGraph[N, E]) IsDirected() bool {
 	return true
 }
 
-func (rg rawDirectedGraph) removeRefs() Graph {
-	for _, edges := range rg.FromToEdges {
-		for _, edge := range edges {
-			edge.RawGraphRef = nil
+// removeRefs returns a copy of the graph whose nodes and edges do not point back to it, leaving rg untouched.
+func (rg directedGraph[N, E]) removeRefs() TypedGraph[N, E] {
+	copyEdge := func(edge *directedEdge[N, E]) *directedEdge[N, E] {
+		if edge == nil {
+			return nil
 		}
+		copied := *edge
+		copied.RawGraphRef = nil
+		return &copied
 	}
-	for _, node := range rg.Nodes {
-		node.RawGraphRef = nil
+	copied := directedGraph[N, E]{
+		FromToEdges: make(map[NodeID]map[NodeID]*directedEdge[N, E], len(rg.FromToEdges)),
+		Nodes:       make(map[NodeID]*directedNode[N, E], len(rg.Nodes)),
+	}
+	for from, edges := range rg.FromToEdges {
+		copied.FromToEdges[from] = make(map[NodeID]*directedEdge[N, E], len(edges))
+		for to, edge := range edges {
+			copied.FromToEdges[from][to] = copyEdge(edge)
+		}
+	}
+	if rg.Parallel != nil {
+		copied.Parallel = make(map[NodeID]map[NodeID][]*directedEdge[N, E], len(rg.Parallel))
+		for from, subEdges := range rg.Parallel {
+			copied.Parallel[from] = make(map[NodeID][]*directedEdge[N, E], len(subEdges))
+			for to, edges := range subEdges {
+				for _, edge := range edges {
+					copied.Parallel[from][to] = append(copied.Parallel[from][to], copyEdge(edge))
+				}
+			}
+		}
 	}
-	return rg
+	for id, node := range rg.Nodes {
+		copiedNode := *node
+		copiedNode.RawGraphRef = nil
+		copied.Nodes[id] = &copiedNode
+	}
+	return copied
 }
 
-type rawUndirectedGraph struct {
-	Edges      []*rawUndirectedEdge
-	NodesEdges map[NodeID]map[NodeID]*rawUndirectedEdge
-	Nodes      map[NodeID]*rawUndirectedNode
+type rawUndirectedGraph = undirectedGraph[interface{}, interface{}]
+
+type undirectedGraph[N, E any] struct {
+	Edges      []*undirectedEdge[N, E]
+	NodesEdges map[NodeID]map[NodeID]*undirectedEdge[N, E]
+	Nodes      map[NodeID]*undirectedNode[N, E]
+	// Parallel maps both first-second and second-first to the edges after the first one, sorted by index.
+	// It stays nil unless the graph has parallel edges.
+	Parallel map[NodeID]map[NodeID][]*undirectedEdge[N, E]
 }
 
-func (rg rawUndirectedGraph) GetNode(id NodeID) (Node, error) {
+func (rg undirectedGraph[N, E]) GetNode(id NodeID) (TypedNode[N, E], error) {
 	node, exists := rg.Nodes[id]
 	if !exists {
-		return nil, nodeNotFoundError{nodeID: id}
+		return nil, NodeNotFoundError{NodeID: id}
 	}
 	return node, nil
 }
 
-func (rg rawUndirectedGraph) GetEdge(first NodeID, second NodeID) (Edge, error) {
+func (rg undirectedGraph[N, E]) GetEdge(first NodeID, second NodeID) (TypedEdge[N, E], error) {
 	if _, firstExists := rg.NodesEdges[first]; firstExists {
 		if edge, secondExists := rg.NodesEdges[first][second]; secondExists {
 			return edge, nil
 		}
 	}
-	return nil, edgeNotFoundError{fromID: first, toID: second}
+	return nil, EdgeNotFoundError{FromID: first, ToID: second}
 }
 
-func (rg rawUndirectedGraph) GetNodes() ([]Node, error) {
-	nodes := make([]Node, 0)
+func (rg undirectedGraph[N, E]) GetEdgesBetween(first NodeID, second NodeID) ([]TypedEdge[N, E], error) {
+	edge, err := rg.GetEdge(first, second)
+	if err != nil {
+		return nil, err
+	}
+	edges := []TypedEdge[N, E]{edge}
+	for _, parallelEdge := range rg.Parallel[first][second] {
+		edges = append(edges, parallelEdge)
+	}
+	return edges, nil
+}
+
+func (rg undirectedGraph[N, E]) GetNodes() ([]TypedNode[N, E], error) {
+	nodes := make([]TypedNode[N, E], 0)
 	for _, node := range rg.Nodes {
 		nodes = append(nodes, node)
 	}
@@ -129,24 +219,90 @@ func (rg rawUndirectedGraph) GetNodes() ([]Node, error) {
 	return nodes, nil
 }
 
-func (rg rawUndirectedGraph) GetEdges() ([]Edge, error) {
-	edges := make([]Edge, 0)
+func (rg undirectedGraph[N, E]) GetEdges() ([]TypedEdge[N, E], error) {
+	edges := make([]TypedEdge[N, E], 0)
 	for _, edge := range rg.Edges {
 		edges = append(edges, *edge)
 	}
 	return edges, nil
 }
 
-func (g rawUndirectedGraph) IsDirected() bool {
+func (rg *undirectedGraph[N, E]) addParallelEdge(edge *undirectedEdge[N, E]) {
+	if rg.Parallel == nil {
+		rg.Parallel = make(map[NodeID]map[NodeID][]*undirectedEdge[N, E])
+	}
+	first, second := edge.Nodes[0], edge.Nodes[1]
+	if _, firstExists := rg.Parallel[first]; !firstExists {
+		rg.Parallel[first] = make(map[NodeID][]*undirectedEdge[N, E])
+	}
+	if _, secondExists := rg.Parallel[second]; !secondExists {
+		rg.Parallel[second] = make(map[NodeID][]*undirectedEdge[N, E])
+	}
+	rg.Parallel[first][second] = append(rg.Parallel[first][second], edge)
+	if first != second {
+		rg.Parallel[second][first] = append(rg.Parallel[second][first], edge)
+	}
+}
+
+func lessUndirectedEdge[N, E any](a *undirectedEdge[N, E], b *undirectedEdge[N, E]) bool {
+	if a.Nodes[0] != b.Nodes[0] {
+		return a.Nodes[0] < b.Nodes[0]
+	}
+	if a.Nodes[1] != b.Nodes[1] {
+		return a.Nodes[1] < b.Nodes[1]
+	}
+	return a.Index < b.Index
+}
+
+func (g undirectedGraph[N, E]) IsDirected() bool {
 	return false
 }
 
-func (rg rawUndirectedGraph) removeRefs() Graph {
+// removeRefs returns a copy of the graph whose nodes and edges do not point back to it, leaving rg untouched.
+func (rg undirectedGraph[N, E]) removeRefs() TypedGraph[N, E] {
+	// an edge is shared by Edges, NodesEdges and Parallel, so it is copied once and the copy shared the same way
+	copiedEdges := make(map[*undirectedEdge[N, E]]*undirectedEdge[N, E], len(rg.Edges))
+	copyEdge := func(edge *undirectedEdge[N, E]) *undirectedEdge[N, E] {
+		if edge == nil {
+			return nil
+		}
+		if copiedEdge, exists := copiedEdges[edge]; exists {
+			return copiedEdge
+		}
+		copiedEdge := *edge
+		copiedEdge.RawGraphRef = nil
+		copiedEdges[edge] = &copiedEdge
+		return &copiedEdge
+	}
+	copied := undirectedGraph[N, E]{
+		Edges:      make([]*undirectedEdge[N, E], 0, len(rg.Edges)),
+		NodesEdges: make(map[NodeID]map[NodeID]*undirectedEdge[N, E], len(rg.NodesEdges)),
+		Nodes:      make(map[NodeID]*undirectedNode[N, E], len(rg.Nodes)),
+	}
 	for _, edge := range rg.Edges {
-		edge.RawGraphRef = nil
+		copied.Edges = append(copied.Edges, copyEdge(edge))
 	}
-	for _, node := range rg.Nodes {
-		node.RawGraphRef = nil
+	for first, edges := range rg.NodesEdges {
+		copied.NodesEdges[first] = make(map[NodeID]*undirectedEdge[N, E], len(edges))
+		for second, edge := range edges {
+			copied.NodesEdges[first][second] = copyEdge(edge)
+		}
+	}
+	if rg.Parallel != nil {
+		copied.Parallel = make(map[NodeID]map[NodeID][]*undirectedEdge[N, E], len(rg.Parallel))
+		for first, subEdges := range rg.Parallel {
+			copied.Parallel[first] = make(map[NodeID][]*undirectedEdge[N, E], len(subEdges))
+			for second, edges := range subEdges {
+				for _, edge := range edges {
+					copied.Parallel[first][second] = append(copied.Parallel[first][second], copyEdge(edge))
+				}
+			}
+		}
+	}
+	for id, node := range rg.Nodes {
+		copiedNode := *node
+		copiedNode.RawGraphRef = nil
+		copied.Nodes[id] = &copiedNode
 	}
-	return rg
+	return copied
 }
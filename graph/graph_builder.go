@@ -4,38 +4,110 @@ import (
 	"sort"
 )
 
-// GraphBuilder uses the builder pattern to create directed or undirected graphs.
-type GraphBuilder interface {
+// GraphBuilder uses the builder pattern to create directed or undirected graphs with untyped values.
+type GraphBuilder = TypedGraphBuilder[interface{}, interface{}]
+
+// TypedGraphBuilder uses the builder pattern to create directed or undirected graphs
+// that store values of type N in their nodes and values of type E in their edges.
+type TypedGraphBuilder[N, E any] interface {
 	// AddNode adds a node to the current graph current being built.
 	// The id parameter is the unique id used to identify this node.
 	// The value parameter can optionally be used to store a value in this node.
 	// Additionally, AddEdge will connect nodes added via their ids.
-	AddNode(id NodeID, value ...interface{})
+	AddNode(id NodeID, value ...N)
 
 	// AddEdge adds an edge connecting two nodes.
 	// In a directed graph, it uses the fromID parameter and toID parameter to connect an edge from the former to the latter.
 	// In an undirected graph, it will create a undirected edge between the two.
 	// The value parameter can optionally be used to store a value in this edge.
-	AddEdge(from NodeID, to NodeID, value ...interface{})
+	AddEdge(from NodeID, to NodeID, value ...E)
 
 	// Build creates a directed/indirect graph using the ndoes and edges created above.
 	// Returns an error if any of the aforementioned errors is detected.
-	Build() (Graph, error)
+	Build() (TypedGraph[N, E], error)
+
+	// BuildMutable is like Build but the resulting graph can still be edited.
+	// The builder options are carried over and validate every later edit.
+	BuildMutable() (TypedMutableGraph[N, E], error)
 }
 
-type wrappedValue struct {
+type wrappedValue = typedValue[interface{}]
+
+// typedValue is an optional value of type T stored in a node or edge.
+type typedValue[T any] struct {
 	HasValue bool
-	RawValue interface{}
+	RawValue T
+}
+
+func wrapValue[T any](value []T) typedValue[T] {
+	wv := typedValue[T]{}
+	if len(value) == 1 {
+		wv.HasValue = true
+		wv.RawValue = value[0]
+	}
+	return wv
 }
 
-type rawGraphBuilder struct {
+// builderEdge is an edge added to a builder. Its index is its position among the parallel edges
+// between the same nodes unless it was added by addEdgeWithIndex.
+type builderEdge[E any] struct {
+	index int
+	value typedValue[E]
+}
+
+type graphBuilder[N, E any] struct {
 	builderOptions BuilderOptions
-	nodes          map[NodeID]wrappedValue
-	edges          map[NodeID]map[NodeID]wrappedValue
+	nodes          map[NodeID]typedValue[N]
+	edges          map[NodeID]map[NodeID][]builderEdge[E]
 	err            error
+	// calls counts the AddNode and AddEdge calls made so far.
+	calls int
+	// invalidCalls and edgeCalls are only kept if CollectAllErrors is set.
+	invalidCalls []CallError
+	edgeCalls    []edgeCall
 }
 
-func (builder *rawGraphBuilder) AddNode(id NodeID, value ...interface{}) {
+// edgeCall is a valid AddEdge call whose nodes are checked once the graph is built.
+type edgeCall struct {
+	index int
+	from  NodeID
+	to    NodeID
+}
+
+// fail records the error of the current call. Unless all errors are collected,
+// the first error stops the builder and is returned by Build.
+func (builder *graphBuilder[N, E]) fail(err error) {
+	if builder.builderOptions.CollectAllErrors {
+		builder.invalidCalls = append(builder.invalidCalls, CallError{Index: builder.calls - 1, Err: err})
+		return
+	}
+	builder.err = err
+}
+
+// buildError returns the error Build should return, if any.
+func (builder *graphBuilder[N, E]) buildError() error {
+	if !builder.builderOptions.CollectAllErrors {
+		return builder.err
+	}
+	invalidCalls := append([]CallError{}, builder.invalidCalls...)
+	for _, call := range builder.edgeCalls {
+		if _, exists := builder.nodes[call.from]; !exists {
+			invalidCalls = append(invalidCalls, CallError{Index: call.index, Err: NodeNotFoundError{NodeID: call.from}})
+		} else if _, exists := builder.nodes[call.to]; !exists {
+			invalidCalls = append(invalidCalls, CallError{Index: call.index, Err: NodeNotFoundError{NodeID: call.to}})
+		}
+	}
+	if len(invalidCalls) == 0 {
+		return nil
+	}
+	sort.SliceStable(invalidCalls, func(i, j int) bool {
+		return invalidCalls[i].Index < invalidCalls[j].Index
+	})
+	return BuilderError{Calls: invalidCalls}
+}
+
+func (builder *graphBuilder[N, E]) AddNode(id NodeID, value ...N) {
+	builder.calls++
 	// if there is an existing error skip this command
 	if builder.err != nil {
 		return
@@ -43,25 +115,20 @@ func (builder *rawGraphBuilder) AddNode(id NodeID, value ...interface{}) {
 
 	// check if node exists and that duplicate nodes are not allowed
 	if _, exists := builder.nodes[id]; exists && !builder.builderOptions.AllowDuplicateNodes {
-		builder.err = duplicateNodeError{nodeID: id}
+		builder.fail(DuplicateNodeError{NodeID: id})
 		return
 	}
 
 	// check if multiple values are provided
 	if len(value) > 1 {
-		builder.err = multipleValuesForNodeError{nodeID: id}
+		builder.fail(MultipleValuesForNodeError{NodeID: id})
 		return
 	}
 
-	wv := wrappedValue{}
-	if len(value) == 1 {
-		wv.HasValue = true
-		wv.RawValue = value[0]
-	}
-	builder.nodes[id] = wv
+	builder.nodes[id] = wrapValue(value)
 }
 
-func (builder *rawGraphBuilder) addEdgeHelper(from NodeID, to NodeID, value ...interface{}) {
+func (builder *graphBuilder[N, E]) addEdgeHelper(from NodeID, to NodeID, value ...E) {
 	// ensures that addEdgeHelper(8, 9) and addEdgeHelper(9, 8) only add one edge
 	if !builder.builderOptions.IsDirected && from > to {
 		from, to = to, from
@@ -73,29 +140,36 @@ func (builder *rawGraphBuilder) addEdgeHelper(from NodeID, to NodeID, value ...i
 			edgeExists = true
 		}
 	} else {
-		builder.edges[from] = make(map[NodeID]wrappedValue)
+		builder.edges[from] = make(map[NodeID][]builderEdge[E])
 	}
-	if edgeExists && !builder.builderOptions.AllowDuplicateEdges {
-		builder.err = duplicateEdgeError{fromID: from, toID: to}
+	allowParallel := builder.builderOptions.AllowParallelEdges
+	if edgeExists && !allowParallel && !builder.builderOptions.AllowDuplicateEdges {
+		builder.fail(DuplicateEdgeError{FromID: from, ToID: to})
 		return
 	}
 
 	// check if multiple values are provided
 	if len(value) > 1 {
-		builder.err = multipleValuesForEdgeError{fromID: from, toID: to}
+		builder.fail(MultipleValuesForEdgeError{FromID: from, ToID: to})
 		return
 	}
 
+	if builder.builderOptions.CollectAllErrors {
+		builder.edgeCalls = append(builder.edgeCalls, edgeCall{index: builder.calls - 1, from: from, to: to})
+	}
+
 	// add edge with from as the first enty and to as the second entry
-	wv := wrappedValue{}
-	if len(value) == 1 {
-		wv.HasValue = true
-		wv.RawValue = value[0]
+	// parallel edges are kept in the order they were added
+	if edgeExists && allowParallel {
+		index := len(builder.edges[from][to])
+		builder.edges[from][to] = append(builder.edges[from][to], builderEdge[E]{index: index, value: wrapValue(value)})
+		return
 	}
-	builder.edges[from][to] = wv
+	builder.edges[from][to] = []builderEdge[E]{{index: 0, value: wrapValue(value)}}
 }
 
-func (builder *rawGraphBuilder) AddEdge(fromID NodeID, toID NodeID, value ...interface{}) {
+func (builder *graphBuilder[N, E]) AddEdge(fromID NodeID, toID NodeID, value ...E) {
+	builder.calls++
 	// if there is an existing error skip this command
 	if builder.err != nil {
 		return
@@ -104,53 +178,62 @@ func (builder *rawGraphBuilder) AddEdge(fromID NodeID, toID NodeID, value ...int
 	// check if both nodes exist and if build edges incrementally is enabled
 	buildIncrementally := builder.builderOptions.BuildEdgesIncrementally
 	if _, existsFrom := builder.nodes[fromID]; !existsFrom && buildIncrementally {
-		builder.err = nodeNotFoundError{nodeID: fromID}
+		builder.fail(NodeNotFoundError{NodeID: fromID})
 		return
 	}
 	if _, existsTo := builder.nodes[toID]; !existsTo && buildIncrementally {
-		builder.err = nodeNotFoundError{nodeID: toID}
+		builder.fail(NodeNotFoundError{NodeID: toID})
 		return
 	}
 
 	// check that edge is not redundant
 	if fromID == toID && !builder.builderOptions.AllowRedundantEdges {
-		builder.err = redundantEdgeError{nodeID: fromID}
+		builder.fail(RedundantEdgeError{NodeID: fromID})
 		return
 	}
 
 	builder.addEdgeHelper(fromID, toID, value...)
 }
 
-func (builder *rawGraphBuilder) buildUndirectedGraph() (Graph, error) {
-	graph := rawUndirectedGraph{
-		Edges:      make([]*rawUndirectedEdge, 0),
-		NodesEdges: make(map[NodeID]map[NodeID]*rawUndirectedEdge),
-		Nodes:      make(map[NodeID]*rawUndirectedNode),
+// addEdgeWithIndex is like AddEdge but gives the edge the given index instead of its position among the parallel edges.
+// The parallel edges between two nodes must be added in ascending order of index.
+func (builder *graphBuilder[N, E]) addEdgeWithIndex(fromID NodeID, toID NodeID, index int, value ...E) {
+	invalidCalls := len(builder.invalidCalls)
+	builder.AddEdge(fromID, toID, value...)
+	if builder.err != nil || len(builder.invalidCalls) > invalidCalls {
+		return
+	}
+	if !builder.builderOptions.IsDirected && fromID > toID {
+		fromID, toID = toID, fromID
+	}
+	edges := builder.edges[fromID][toID]
+	edges[len(edges)-1].index = index
+}
+
+func (builder *graphBuilder[N, E]) buildUndirectedGraph() (*undirectedGraph[N, E], error) {
+	graph := &undirectedGraph[N, E]{
+		Edges:      make([]*undirectedEdge[N, E], 0),
+		NodesEdges: make(map[NodeID]map[NodeID]*undirectedEdge[N, E]),
+		Nodes:      make(map[NodeID]*undirectedNode[N, E]),
 	}
 
 	// map nodeIDs to nodes
 	for id, val := range builder.nodes {
-		graph.Nodes[id] = &rawUndirectedNode{
+		graph.Nodes[id] = &undirectedNode[N, E]{
 			ID:          id,
 			Neighbors:   make([]NodeID, 0),
-			RawGraphRef: &graph,
+			RawGraphRef: graph,
 			Value:       val,
 		}
 	}
 
 	for first, toVals := range builder.edges {
-		for second, val := range toVals {
-			// construct edge
-			edge := rawUndirectedEdge{
-				Nodes:       [2]NodeID{first, second},
-				RawGraphRef: &graph,
-				Value:       val,
-			}
+		for second, vals := range toVals {
 			// add each node to the other node's neighbor list, throw error if it does not exist
 			if firstNode, firstNodeExists := graph.Nodes[first]; firstNodeExists {
 				firstNode.Neighbors = append(firstNode.Neighbors, second)
 			} else {
-				return nil, &nodeNotFoundError{nodeID: first}
+				return nil, NodeNotFoundError{NodeID: first}
 			}
 
 			// if first and second are equal to eah other (i.e. self loop) then only add once
@@ -159,21 +242,37 @@ func (builder *rawGraphBuilder) buildUndirectedGraph() (Graph, error) {
 					secondNode.Neighbors = append(secondNode.Neighbors, first)
 				}
 			} else {
-				return nil, &nodeNotFoundError{nodeID: second}
+				return nil, NodeNotFoundError{NodeID: second}
 			}
 
-			// map first, second and second, first to pointer to edge
-			if _, firstExists := graph.NodesEdges[first]; !firstExists {
-				graph.NodesEdges[first] = make(map[NodeID]*rawUndirectedEdge)
-			}
-			if _, secondExists := graph.NodesEdges[second]; !secondExists {
-				graph.NodesEdges[second] = make(map[NodeID]*rawUndirectedEdge)
-			}
-			graph.NodesEdges[first][second] = &edge
-			graph.NodesEdges[second][first] = &edge
+			for position, val := range vals {
+				// construct edge
+				edge := &undirectedEdge[N, E]{
+					Nodes:       [2]NodeID{first, second},
+					Index:       val.index,
+					RawGraphRef: graph,
+					Value:       val.value,
+				}
+
+				// add edge to list of edges to maintain list of unique edges
+				graph.Edges = append(graph.Edges, edge)
 
-			// add edge to list of edges to maintain list of unique edges
-			graph.Edges = append(graph.Edges, &edge)
+				// parallel edges after the first one are kept separately
+				if position > 0 {
+					graph.addParallelEdge(edge)
+					continue
+				}
+
+				// map first, second and second, first to pointer to edge
+				if _, firstExists := graph.NodesEdges[first]; !firstExists {
+					graph.NodesEdges[first] = make(map[NodeID]*undirectedEdge[N, E])
+				}
+				if _, secondExists := graph.NodesEdges[second]; !secondExists {
+					graph.NodesEdges[second] = make(map[NodeID]*undirectedEdge[N, E])
+				}
+				graph.NodesEdges[first][second] = edge
+				graph.NodesEdges[second][first] = edge
+			}
 		}
 	}
 
@@ -187,57 +286,65 @@ func (builder *rawGraphBuilder) buildUndirectedGraph() (Graph, error) {
 			edge.Nodes[0], edge.Nodes[1] = edge.Nodes[1], edge.Nodes[0]
 		}
 	}
-	// sort graph.edges by edge.nodes
+	// sort graph.edges by edge.nodes then by edge.index
 	sort.Slice(graph.Edges, func(i, j int) bool {
-		if graph.Edges[i].Nodes[0] != graph.Edges[j].Nodes[0] {
-			return graph.Edges[i].Nodes[0] < graph.Edges[j].Nodes[0]
-		}
-		return graph.Edges[i].Nodes[1] < graph.Edges[j].Nodes[1]
+		return lessUndirectedEdge(graph.Edges[i], graph.Edges[j])
 	})
 	return graph, nil
 }
 
-func (builder *rawGraphBuilder) buildDirectedGraph() (Graph, error) {
-	graph := rawDirectedGraph{
-		FromToEdges: make(map[NodeID]map[NodeID]*rawDirectedEdge),
-		Nodes:       make(map[NodeID]*rawDirectedNode),
+func (builder *graphBuilder[N, E]) buildDirectedGraph() (*directedGraph[N, E], error) {
+	graph := &directedGraph[N, E]{
+		FromToEdges: make(map[NodeID]map[NodeID]*directedEdge[N, E]),
+		Nodes:       make(map[NodeID]*directedNode[N, E]),
 	}
 
 	// map nodeIDs to nodes
 	for id, val := range builder.nodes {
-		graph.Nodes[id] = &rawDirectedNode{
+		graph.Nodes[id] = &directedNode[N, E]{
 			ID:          id,
 			Outgoing:    make([]NodeID, 0),
 			Incoming:    make([]NodeID, 0),
-			RawGraphRef: &graph,
+			RawGraphRef: graph,
 			Value:       val,
 		}
 	}
 
 	// map from-to to edges
 	for from, toVals := range builder.edges {
-		for to, val := range toVals {
+		for to, vals := range toVals {
 			// add from to the outgoing list of to and vice versa, throw err if they don't exist
 			if fromNode, fromNodeExists := graph.Nodes[from]; fromNodeExists {
 				fromNode.Outgoing = append(fromNode.Outgoing, to)
 			} else {
-				return nil, &nodeNotFoundError{nodeID: from}
+				return nil, NodeNotFoundError{NodeID: from}
 			}
 			if toNode, toNodeExists := graph.Nodes[to]; toNodeExists {
 				toNode.Incoming = append(toNode.Incoming, from)
 			} else {
-				return nil, &nodeNotFoundError{nodeID: to}
+				return nil, NodeNotFoundError{NodeID: to}
 			}
 
-			// map from-to to edge
-			if _, fromExists := graph.FromToEdges[from]; !fromExists {
-				graph.FromToEdges[from] = make(map[NodeID]*rawDirectedEdge)
-			}
-			graph.FromToEdges[from][to] = &rawDirectedEdge{
-				From:        from,
-				To:          to,
-				RawGraphRef: &graph,
-				Value:       val,
+			for position, val := range vals {
+				edge := &directedEdge[N, E]{
+					From:        from,
+					To:          to,
+					Index:       val.index,
+					RawGraphRef: graph,
+					Value:       val.value,
+				}
+
+				// parallel edges after the first one are kept separately
+				if position > 0 {
+					graph.addParallelEdge(edge)
+					continue
+				}
+
+				// map from-to to edge
+				if _, fromExists := graph.FromToEdges[from]; !fromExists {
+					graph.FromToEdges[from] = make(map[NodeID]*directedEdge[N, E])
+				}
+				graph.FromToEdges[from][to] = edge
 			}
 		}
 	}
@@ -250,14 +357,47 @@ func (builder *rawGraphBuilder) buildDirectedGraph() (Graph, error) {
 	return graph, nil
 }
 
-func (builder *rawGraphBuilder) Build() (Graph, error) {
-	if builder.err != nil {
-		return nil, builder.err
+func (builder *graphBuilder[N, E]) Build() (TypedGraph[N, E], error) {
+	if err := builder.buildError(); err != nil {
+		return nil, err
+	}
+	if builder.builderOptions.Storage == CSRStorage {
+		graph, err := builder.buildCSRGraph()
+		if err != nil {
+			return nil, err
+		}
+		return graph, nil
 	}
 	if builder.builderOptions.IsDirected {
-		return builder.buildDirectedGraph()
+		graph, err := builder.buildDirectedGraph()
+		if err != nil {
+			return nil, err
+		}
+		return *graph, nil
 	}
-	return builder.buildUndirectedGraph()
+	graph, err := builder.buildUndirectedGraph()
+	if err != nil {
+		return nil, err
+	}
+	return *graph, nil
+}
+
+func (builder *graphBuilder[N, E]) BuildMutable() (TypedMutableGraph[N, E], error) {
+	if err := builder.buildError(); err != nil {
+		return nil, err
+	}
+	if builder.builderOptions.IsDirected {
+		graph, err := builder.buildDirectedGraph()
+		if err != nil {
+			return nil, err
+		}
+		return &mutableDirectedGraph[N, E]{directedGraph: graph, builderOptions: builder.builderOptions}, nil
+	}
+	graph, err := builder.buildUndirectedGraph()
+	if err != nil {
+		return nil, err
+	}
+	return &mutableUndirectedGraph[N, E]{undirectedGraph: graph, builderOptions: builder.builderOptions}, nil
 }
 
 // BuilderOptions determine what is or isn't allowed in during the construction of a graph.
@@ -271,26 +411,44 @@ type BuilderOptions struct {
 	// The value of the node id can change with each call, but the graph will use the last one.
 	// If false, Build will return a duplicate node id error.
 	AllowDuplicateNodes bool
+	// AllowParallelEdges will allow AddEdge(a, b, val) to be executed multiple times if set to true.
+	// Each call adds a separate edge a-b with its own value and EdgeID, so AllowDuplicateEdges is ignored.
+	// The parallel edges are indexed in the order they were added.
+	AllowParallelEdges bool
 	// AllowRedundantEdges will allow AddEdge(a, a, val) to create a self-loop on node a if set to true.
 	// If false, Build will return a redundant edge a-a error.
 	AllowRedundantEdges bool
+	// CollectAllErrors will make the builder skip invalid AddNode and AddEdge calls instead of stopping at the first one if set to true.
+	// Build then returns a BuilderError listing every invalid call along with its index.
+	// If false, Build will return the error of the first invalid call.
+	CollectAllErrors bool
 	// BuildEdgesIncrementally will allow creation of a graph where AddEdge(a, b, val) occurs before AddNode(a) and/or AddNode(b) if set to true.
 	// If false, Build will return a node a/b not found error
 	BuildEdgesIncrementally bool
 	// IsDirected tells the builder to create a directed graph if set to true.
 	// If false, builder will assume undirected graph.
 	IsDirected bool
+	// Storage selects how Build stores the graph. It defaults to MapStorage.
+	// BuildMutable always uses MapStorage.
+	Storage StorageBackend
 }
 
+// NewGraphBuilder creates a builder for a graph with untyped node and edge values.
 func NewGraphBuilder(bo ...BuilderOptions) GraphBuilder {
+	return NewTypedGraphBuilder[interface{}, interface{}](bo...)
+}
+
+// NewTypedGraphBuilder creates a builder for a graph that stores values of type N
+// in its nodes and values of type E in its edges.
+func NewTypedGraphBuilder[N, E any](bo ...BuilderOptions) TypedGraphBuilder[N, E] {
 	builderOptions := BuilderOptions{}
 	if len(bo) == 1 {
 		builderOptions = bo[0]
 	}
-	return &rawGraphBuilder{
+	return &graphBuilder[N, E]{
 		builderOptions: builderOptions,
-		nodes:          make(map[NodeID]wrappedValue),
-		edges:          make(map[NodeID]map[NodeID]wrappedValue),
+		nodes:          make(map[NodeID]typedValue[N]),
+		edges:          make(map[NodeID]map[NodeID][]builderEdge[E]),
 		err:            nil,
 	}
 }
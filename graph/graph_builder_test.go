@@ -8,14 +8,63 @@ import (
 )
 
 func AssertGraphEquals(t *testing.T, expected, actual Graph) bool {
-	refLessExpected := expected.removeRefs()
-	refLessActual := actual.removeRefs()
+	refLessExpected := refLessGraph(t, expected)
+	refLessActual := refLessGraph(t, actual)
 	return assert.True(t,
 		cmp.Equal(refLessExpected, refLessActual),
 		cmp.Diff(refLessExpected, refLessActual),
 	)
 }
 
+func Test_AssertGraphEquals_LeavesGraphsUntouched(t *testing.T) {
+	for _, bo := range []BuilderOptions{{AllowParallelEdges: true}, {AllowParallelEdges: true, IsDirected: true}} {
+		gb := NewGraphBuilder(bo)
+		gb.AddNode(1)
+		gb.AddNode(2)
+		gb.AddEdge(1, 2, "a")
+		gb.AddEdge(1, 2, "b")
+		graph, err := gb.Build()
+		assert.NoError(t, err)
+		AssertGraphEquals(t, graph, graph)
+		edges, err := graph.GetEdgesBetween(1, 2)
+		assert.NoError(t, err)
+		for _, edge := range edges {
+			actual_nodes, err := edge.GetNodes()
+			assert.NoError(t, err)
+			assert.Equal(t, []NodeID{1, 2}, nodeIDs(actual_nodes))
+		}
+		node, err := graph.GetNode(1)
+		assert.NoError(t, err)
+		actual_edges, err := node.GetIncidentEdges()
+		assert.NoError(t, err)
+		assert.Len(t, actual_edges, 2)
+	}
+}
+
+// refLessGraph removes the references from a graph stored in maps so that it can be compared with cmp.
+// Any other graph, such as a CSR graph, is first copied into maps through the Graph interface.
+func refLessGraph(t *testing.T, g Graph) Graph {
+	switch g.(type) {
+	case rawDirectedGraph, rawUndirectedGraph:
+		return g.removeRefs()
+	}
+	builder := transformBuilder[interface{}, interface{}](g.IsDirected(), MapStorage)
+	nodes, err := g.GetNodes()
+	assert.NoError(t, err)
+	for _, node := range nodes {
+		builder.AddNode(node.GetID(), nodeValue(node).values()...)
+	}
+	edges, err := g.GetEdges()
+	assert.NoError(t, err)
+	for _, edge := range edges {
+		id := edge.GetID()
+		builder.addEdgeWithIndex(id.From, id.To, id.Index, edgeValue(edge).values()...)
+	}
+	copied, err := builder.Build()
+	assert.NoError(t, err)
+	return copied.removeRefs()
+}
+
 func Test_UndirectedTree(t *testing.T) {
 	// build undirected graph
 	graphBuilder := NewGraphBuilder()
@@ -232,7 +281,7 @@ func Test_UndirectedWithDuplicateNode(t *testing.T) {
 	gb.AddNode(1)
 	gb.AddNode(1)
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, duplicateNodeError{nodeID: 1})
+	assert.ErrorIs(t, err, DuplicateNodeError{NodeID: 1})
 
 	gb = NewGraphBuilder(BuilderOptions{AllowDuplicateNodes: true})
 	gb.AddNode(1)
@@ -254,7 +303,7 @@ func Test_DirectedWithDuplicateNode(t *testing.T) {
 	gb.AddNode(1)
 	gb.AddNode(1)
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, duplicateNodeError{nodeID: 1})
+	assert.ErrorIs(t, err, DuplicateNodeError{NodeID: 1})
 
 	gb = NewGraphBuilder(BuilderOptions{AllowDuplicateNodes: true, IsDirected: true})
 	gb.AddNode(1)
@@ -277,7 +326,7 @@ func Test_UndirectedWithDuplicateEdge(t *testing.T) {
 	gb.AddEdge(1, 2)
 	gb.AddEdge(1, 2)
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, duplicateEdgeError{fromID: 1, toID: 2})
+	assert.ErrorIs(t, err, DuplicateEdgeError{FromID: 1, ToID: 2})
 
 	gb = NewGraphBuilder(BuilderOptions{AllowDuplicateEdges: true})
 	gb.AddNode(1)
@@ -317,7 +366,7 @@ func Test_DirectedWithDuplicateEdge(t *testing.T) {
 	gb.AddEdge(1, 2)
 	gb.AddEdge(1, 2)
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, duplicateEdgeError{fromID: 1, toID: 2})
+	assert.ErrorIs(t, err, DuplicateEdgeError{FromID: 1, ToID: 2})
 
 	gb = NewGraphBuilder(BuilderOptions{AllowDuplicateEdges: true, IsDirected: true})
 	gb.AddNode(1)
@@ -349,7 +398,7 @@ func Test_UndirectedWithRedundantEdge(t *testing.T) {
 	gb.AddNode(1)
 	gb.AddEdge(1, 1)
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, redundantEdgeError{nodeID: 1})
+	assert.ErrorIs(t, err, RedundantEdgeError{NodeID: 1})
 
 	gb = NewGraphBuilder(BuilderOptions{AllowRedundantEdges: true})
 	gb.AddNode(1)
@@ -379,7 +428,7 @@ func Test_DirectedWithRedundantEdge(t *testing.T) {
 	gb.AddNode(1)
 	gb.AddEdge(1, 1)
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, redundantEdgeError{nodeID: 1})
+	assert.ErrorIs(t, err, RedundantEdgeError{NodeID: 1})
 
 	gb = NewGraphBuilder(BuilderOptions{AllowRedundantEdges: true, IsDirected: true})
 	gb.AddNode(1)
@@ -406,14 +455,14 @@ func Test_UndirectedWithMultipleValuesForNode(t *testing.T) {
 	gb := NewGraphBuilder()
 	gb.AddNode(1, "value 1", "value 2")
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, multipleValuesForNodeError{nodeID: 1})
+	assert.ErrorIs(t, err, MultipleValuesForNodeError{NodeID: 1})
 }
 
 func Test_DirectedWithMultipleValuesForNode(t *testing.T) {
 	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
 	gb.AddNode(1, "value 1", "value 2")
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, multipleValuesForNodeError{nodeID: 1})
+	assert.ErrorIs(t, err, MultipleValuesForNodeError{NodeID: 1})
 }
 
 func Test_UndirectedWithMultipleValuesForEdge(t *testing.T) {
@@ -422,7 +471,7 @@ func Test_UndirectedWithMultipleValuesForEdge(t *testing.T) {
 	gb.AddNode(2)
 	gb.AddEdge(1, 2, "value 1", "value 2")
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, multipleValuesForEdgeError{fromID: 1, toID: 2})
+	assert.ErrorIs(t, err, MultipleValuesForEdgeError{FromID: 1, ToID: 2})
 }
 
 func Test_DirectedWithMultipleValuesForEdge(t *testing.T) {
@@ -431,5 +480,170 @@ func Test_DirectedWithMultipleValuesForEdge(t *testing.T) {
 	gb.AddNode(2)
 	gb.AddEdge(1, 2, "value 1", "value 2")
 	_, err := gb.Build()
-	assert.ErrorIs(t, err, multipleValuesForEdgeError{fromID: 1, toID: 2})
+	assert.ErrorIs(t, err, MultipleValuesForEdgeError{FromID: 1, ToID: 2})
+}
+
+func Test_TypedDirectedGraph(t *testing.T) {
+	gb := NewTypedGraphBuilder[string, int](BuilderOptions{IsDirected: true})
+	gb.AddNode(1, "one")
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, 12)
+	actual_graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	expected_graph := directedGraph[string, int]{
+		Nodes: map[NodeID]*directedNode[string, int]{
+			1: {ID: 1, Outgoing: []NodeID{2}, Incoming: []NodeID{}, Value: typedValue[string]{HasValue: true, RawValue: "one"}},
+			2: {ID: 2, Outgoing: []NodeID{}, Incoming: []NodeID{1}},
+		},
+		FromToEdges: map[NodeID]map[NodeID]*directedEdge[string, int]{
+			1: {
+				2: {From: 1, To: 2, Value: typedValue[int]{HasValue: true, RawValue: 12}},
+			},
+		},
+	}
+	assert.True(t,
+		cmp.Equal(expected_graph, actual_graph.removeRefs()),
+		cmp.Diff(expected_graph, actual_graph.removeRefs()),
+	)
+}
+
+func Test_TypedUndirectedGraph_GetValue(t *testing.T) {
+	gb := NewTypedGraphBuilder[string, float64]()
+	gb.AddNode(1, "one")
+	gb.AddNode(2)
+	gb.AddEdge(2, 1, 1.5)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	actual_node_value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "one", actual_node_value)
+
+	node, err = graph.GetNode(2)
+	assert.NoError(t, err)
+	actual_node_value, err = node.GetValue()
+	assert.ErrorIs(t, err, NoValueFoundInNodeError{2})
+	assert.Equal(t, "", actual_node_value)
+
+	edge, err := graph.GetEdge(1, 2)
+	assert.NoError(t, err)
+	actual_edge_value, err := edge.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, actual_edge_value)
+}
+
+func Test_UndirectedWithParallelEdges(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "road a")
+	gb.AddEdge(2, 1, "road b")
+	actual_graph, err := gb.Build()
+	assert.NoError(t, err)
+	first := &rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Value: wrappedValue{HasValue: true, RawValue: "road a"}}
+	second := &rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Index: 1, Value: wrappedValue{HasValue: true, RawValue: "road b"}}
+	expected_graph := rawUndirectedGraph{
+		Nodes: map[NodeID]*rawUndirectedNode{
+			1: {ID: 1, Neighbors: []NodeID{2}},
+			2: {ID: 2, Neighbors: []NodeID{1}},
+		},
+		NodesEdges: map[NodeID]map[NodeID]*rawUndirectedEdge{
+			1: {2: first},
+			2: {1: first},
+		},
+		Parallel: map[NodeID]map[NodeID][]*rawUndirectedEdge{
+			1: {2: {second}},
+			2: {1: {second}},
+		},
+		Edges: []*rawUndirectedEdge{first, second},
+	}
+	AssertGraphEquals(t, expected_graph, actual_graph)
+}
+
+func Test_DirectedWithParallelEdges(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true, IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "road a")
+	gb.AddEdge(1, 2, "road b")
+	gb.AddEdge(2, 1)
+	actual_graph, err := gb.Build()
+	assert.NoError(t, err)
+	expected_graph := rawDirectedGraph{
+		Nodes: map[NodeID]*rawDirectedNode{
+			1: {ID: 1, Incoming: []NodeID{2}, Outgoing: []NodeID{2}},
+			2: {ID: 2, Incoming: []NodeID{1}, Outgoing: []NodeID{1}},
+		},
+		FromToEdges: map[NodeID]map[NodeID]*rawDirectedEdge{
+			1: {2: {From: 1, To: 2, Value: wrappedValue{HasValue: true, RawValue: "road a"}}},
+			2: {1: {From: 2, To: 1}},
+		},
+		Parallel: map[NodeID]map[NodeID][]*rawDirectedEdge{
+			1: {2: {{From: 1, To: 2, Index: 1, Value: wrappedValue{HasValue: true, RawValue: "road b"}}}},
+		},
+	}
+	AssertGraphEquals(t, expected_graph, actual_graph)
+}
+
+func Test_DirectedWithMissingNode(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddEdge(1, 2)
+	_, err := gb.Build()
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 2})
+	var notFound NodeNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, NodeID(2), notFound.NodeID)
+}
+
+func Test_CollectAllErrors(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{CollectAllErrors: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(1)
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 1)
+	gb.AddEdge(2, 2)
+	gb.AddEdge(2, 3, "value 1", "value 2")
+	gb.AddEdge(2, 4)
+	gb.AddNode(4)
+	_, err := gb.Build()
+	expected_error := BuilderError{Calls: []CallError{
+		{Index: 2, Err: DuplicateNodeError{NodeID: 1}},
+		{Index: 4, Err: DuplicateEdgeError{FromID: 1, ToID: 2}},
+		{Index: 5, Err: RedundantEdgeError{NodeID: 2}},
+		{Index: 6, Err: MultipleValuesForEdgeError{FromID: 2, ToID: 3}},
+	}}
+	assert.Equal(t, expected_error, err)
+	assert.ErrorIs(t, err, RedundantEdgeError{NodeID: 2})
+	var duplicateEdge DuplicateEdgeError
+	assert.ErrorAs(t, err, &duplicateEdge)
+	assert.Equal(t, DuplicateEdgeError{FromID: 1, ToID: 2}, duplicateEdge)
+
+	// edges to nodes that are never added are reported at the index of their AddEdge call
+	gb = NewGraphBuilder(BuilderOptions{IsDirected: true, CollectAllErrors: true})
+	gb.AddNode(1)
+	gb.AddEdge(1, 2)
+	gb.AddNode(1, "value")
+	gb.AddEdge(3, 1)
+	_, err = gb.BuildMutable()
+	expected_error = BuilderError{Calls: []CallError{
+		{Index: 1, Err: NodeNotFoundError{NodeID: 2}},
+		{Index: 2, Err: DuplicateNodeError{NodeID: 1}},
+		{Index: 3, Err: NodeNotFoundError{NodeID: 3}},
+	}}
+	assert.Equal(t, expected_error, err)
+
+	// the valid calls still build a graph
+	gb = NewGraphBuilder(BuilderOptions{CollectAllErrors: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	actual_graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = actual_graph.GetEdge(2, 1)
+	assert.NoError(t, err)
 }
@@ -33,7 +33,7 @@ func Test_UndirectedGraphGetNode(t *testing.T) {
 	AssertNodeEquals(t, expected_node, actual_node)
 
 	_, err = graph.GetNode(5)
-	assert.ErrorIs(t, err, nodeNotFoundError{nodeID: 5})
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 5})
 }
 
 func Test_DirectedGraphGetNode(t *testing.T) {
@@ -53,7 +53,7 @@ func Test_DirectedGraphGetNode(t *testing.T) {
 	AssertNodeEquals(t, expected_node, actual_node)
 
 	_, err = graph.GetNode(5)
-	assert.ErrorIs(t, err, nodeNotFoundError{nodeID: 5})
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 5})
 }
 
 func AssertEdgeEquals(t *testing.T, expected, actual Edge) bool {
@@ -82,7 +82,7 @@ func Test_UndirectedGraphGetEdge(t *testing.T) {
 	AssertEdgeEquals(t, expected_edge, actual_edge)
 
 	_, err = graph.GetEdge(1, 3)
-	assert.ErrorIs(t, err, edgeNotFoundError{fromID: 1, toID: 3})
+	assert.ErrorIs(t, err, EdgeNotFoundError{FromID: 1, ToID: 3})
 }
 
 func Test_DirectedGraphGetEdge(t *testing.T) {
@@ -98,7 +98,7 @@ func Test_DirectedGraphGetEdge(t *testing.T) {
 	AssertEdgeEquals(t, expected_edge, actual_edge)
 
 	_, err = graph.GetEdge(2, 1)
-	assert.ErrorIs(t, err, edgeNotFoundError{fromID: 2, toID: 1})
+	assert.ErrorIs(t, err, EdgeNotFoundError{FromID: 2, ToID: 1})
 }
 
 func AssertNodesEquals(t *testing.T, expected, actual []Node) bool {
@@ -211,3 +211,64 @@ func Test_DirectedGraph_IsDirected(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, true, graph.IsDirected())
 }
+
+func Test_UndirectedGraphGetEdgesBetween(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(2, 1, "val1")
+	gb.AddEdge(1, 2, "val2")
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_edges, err := graph.GetEdgesBetween(2, 1)
+	assert.NoError(t, err)
+	expected_edges := []Edge{
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Value: wrappedValue{HasValue: true, RawValue: "val1"}},
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Index: 1, Value: wrappedValue{HasValue: true, RawValue: "val2"}},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+
+	actual_edges, err = graph.GetEdges()
+	assert.NoError(t, err)
+	expected_edges = append(expected_edges, rawUndirectedEdge{Nodes: [2]NodeID{2, 3}})
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+
+	_, err = graph.GetEdgesBetween(1, 3)
+	assert.ErrorIs(t, err, EdgeNotFoundError{FromID: 1, ToID: 3})
+}
+
+func Test_DirectedGraphGetEdgesBetween(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true, IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "val1")
+	gb.AddEdge(2, 1)
+	gb.AddEdge(1, 2, "val2")
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_edges, err := graph.GetEdgesBetween(1, 2)
+	assert.NoError(t, err)
+	expected_edges := []Edge{
+		rawDirectedEdge{From: 1, To: 2, Value: wrappedValue{HasValue: true, RawValue: "val1"}},
+		rawDirectedEdge{From: 1, To: 2, Index: 1, Value: wrappedValue{HasValue: true, RawValue: "val2"}},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+
+	actual_edges, err = graph.GetEdges()
+	assert.NoError(t, err)
+	expected_edges = append(expected_edges, rawDirectedEdge{From: 2, To: 1})
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+
+	node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	actual_edges, err = node.GetIncidentEdges()
+	assert.NoError(t, err)
+	expected_edges = []Edge{
+		rawDirectedEdge{From: 2, To: 1},
+		rawDirectedEdge{From: 1, To: 2, Value: wrappedValue{HasValue: true, RawValue: "val1"}},
+		rawDirectedEdge{From: 1, To: 2, Index: 1, Value: wrappedValue{HasValue: true, RawValue: "val2"}},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+}
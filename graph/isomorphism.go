@@ -0,0 +1,382 @@
+package graph
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NodeMatch tells whether node a of the first graph may be mapped to node b of the second graph.
+type NodeMatch func(a Node, b Node) bool
+
+// EdgeMatch tells whether edge a of the first graph may be mapped to edge b of the second graph.
+type EdgeMatch func(a Edge, b Edge) bool
+
+// Isomorphism maps the id of every node of the first graph to the id of its node in the second graph.
+type Isomorphism map[NodeID]NodeID
+
+// isomorphismGraph indexes a graph by the position of its nodes in GetNodes.
+type isomorphismGraph struct {
+	nodes []Node
+	// out counts the edges from i to j. In an undirected graph it is symmetric and in is the same map.
+	out []map[int]int
+	in  []map[int]int
+	// between holds the parallel edges from i to j.
+	between []map[int][]Edge
+	// neighbors are the sorted positions of the other nodes adjacent to i in either direction.
+	neighbors [][]int
+	// signature is the degree of every node, which mapped nodes must share.
+	signature [][3]int
+}
+
+func newIsomorphismGraph(g Graph) (isomorphismGraph, error) {
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return isomorphismGraph{}, err
+	}
+	ig := isomorphismGraph{
+		nodes:     nodes,
+		out:       make([]map[int]int, len(nodes)),
+		between:   make([]map[int][]Edge, len(nodes)),
+		neighbors: make([][]int, len(nodes)),
+		signature: make([][3]int, len(nodes)),
+	}
+	ig.in = ig.out
+	if g.IsDirected() {
+		ig.in = make([]map[int]int, len(nodes))
+	}
+	positions := make(map[NodeID]int)
+	for i, node := range nodes {
+		positions[node.GetID()] = i
+		ig.out[i] = make(map[int]int)
+		ig.in[i] = make(map[int]int)
+		ig.between[i] = make(map[int][]Edge)
+	}
+
+	edges, err := g.GetEdges()
+	if err != nil {
+		return isomorphismGraph{}, err
+	}
+	for _, edge := range edges {
+		from, to := positions[edge.GetID().From], positions[edge.GetID().To]
+		ig.out[from][to]++
+		ig.between[from][to] = append(ig.between[from][to], edge)
+		if from == to {
+			ig.signature[from][2]++
+			continue
+		}
+		ig.in[to][from]++
+		if !g.IsDirected() {
+			ig.between[to][from] = append(ig.between[to][from], edge)
+		}
+	}
+	for i := range nodes {
+		adjacent := make(map[int]bool)
+		for j, count := range ig.out[i] {
+			ig.signature[i][0] += count
+			adjacent[j] = true
+		}
+		for j, count := range ig.in[i] {
+			ig.signature[i][1] += count
+			adjacent[j] = true
+		}
+		delete(adjacent, i)
+		for j := range adjacent {
+			ig.neighbors[i] = append(ig.neighbors[i], j)
+		}
+		sort.Ints(ig.neighbors[i])
+	}
+	return ig, nil
+}
+
+// vf2State is the partial mapping explored by Isomorphic.
+type vf2State struct {
+	a, b      isomorphismGraph
+	nodeMatch NodeMatch
+	edgeMatch EdgeMatch
+	order     []int
+	// core maps positions of one graph to positions of the other, or -1 if unmapped.
+	coreA, coreB []int
+	// touched counts the mapped neighbors of every node; unmapped nodes with some are in the terminal set.
+	touchedA, touchedB []int
+}
+
+// matchOrder visits the nodes of a breadth first, starting every component at its node with the highest degree,
+// so each node after the first of its component has a mapped neighbor when it is matched.
+func matchOrder(a isomorphismGraph) []int {
+	byDegree := make([]int, len(a.nodes))
+	for i := range byDegree {
+		byDegree[i] = i
+	}
+	sort.SliceStable(byDegree, func(i, j int) bool {
+		return len(a.neighbors[byDegree[i]]) > len(a.neighbors[byDegree[j]])
+	})
+	order := make([]int, 0, len(a.nodes))
+	visited := make([]bool, len(a.nodes))
+	for _, root := range byDegree {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		for queue := []int{root}; len(queue) > 0; queue = queue[1:] {
+			order = append(order, queue[0])
+			for _, neighbor := range a.neighbors[queue[0]] {
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					queue = append(queue, neighbor)
+				}
+			}
+		}
+	}
+	return order
+}
+
+func (s *vf2State) match(depth int) bool {
+	if depth == len(s.order) {
+		return true
+	}
+	u := s.order[depth]
+	// a mapped neighbor of u restricts the candidates to the neighbors of its image
+	candidates := make([]int, 0)
+	anchor := -1
+	for _, neighbor := range s.a.neighbors[u] {
+		if s.coreA[neighbor] >= 0 {
+			anchor = s.coreA[neighbor]
+			break
+		}
+	}
+	if anchor >= 0 {
+		candidates = s.b.neighbors[anchor]
+	} else {
+		for v := range s.b.nodes {
+			candidates = append(candidates, v)
+		}
+	}
+	for _, v := range candidates {
+		if s.coreB[v] >= 0 || !s.feasible(u, v) {
+			continue
+		}
+		s.assign(u, v, 1)
+		if s.match(depth + 1) {
+			return true
+		}
+		s.assign(u, v, -1)
+	}
+	return false
+}
+
+// assign maps u to v if delta is 1 and undoes it if delta is -1.
+func (s *vf2State) assign(u, v int, delta int) {
+	if delta > 0 {
+		s.coreA[u], s.coreB[v] = v, u
+	} else {
+		s.coreA[u], s.coreB[v] = -1, -1
+	}
+	for _, neighbor := range s.a.neighbors[u] {
+		s.touchedA[neighbor] += delta
+	}
+	for _, neighbor := range s.b.neighbors[v] {
+		s.touchedB[neighbor] += delta
+	}
+}
+
+func (s *vf2State) feasible(u, v int) bool {
+	if s.a.signature[u] != s.b.signature[v] || len(s.a.neighbors[u]) != len(s.b.neighbors[v]) {
+		return false
+	}
+	if s.nodeMatch != nil && !s.nodeMatch(s.a.nodes[u], s.b.nodes[v]) {
+		return false
+	}
+
+	// every mapped neighbor of u must be connected to v by as many edges in each direction, and vice versa
+	aTerminal, aNew, bTerminal, bNew := 0, 0, 0, 0
+	for _, w := range s.a.neighbors[u] {
+		x := s.coreA[w]
+		if x < 0 {
+			if s.touchedA[w] > 0 {
+				aTerminal++
+			} else {
+				aNew++
+			}
+			continue
+		}
+		if s.a.out[u][w] != s.b.out[v][x] || s.a.in[u][w] != s.b.in[v][x] {
+			return false
+		}
+		if !s.edgesMatch(u, w, v, x) || !s.edgesMatch(w, u, x, v) {
+			return false
+		}
+	}
+	for _, x := range s.b.neighbors[v] {
+		w := s.coreB[x]
+		if w < 0 {
+			if s.touchedB[x] > 0 {
+				bTerminal++
+			} else {
+				bNew++
+			}
+			continue
+		}
+		if s.a.out[u][w] == 0 && s.a.in[u][w] == 0 {
+			return false
+		}
+	}
+	// look ahead: the unmapped neighbors must split the same way between the terminal set and the rest
+	if aTerminal != bTerminal || aNew != bNew {
+		return false
+	}
+	return s.edgesMatch(u, u, v, v)
+}
+
+// edgesMatch checks that the parallel edges from i to j in a can be paired with the parallel edges from k to l in b.
+func (s *vf2State) edgesMatch(i, j, k, l int) bool {
+	if s.edgeMatch == nil {
+		return true
+	}
+	aEdges, bEdges := s.a.between[i][j], s.b.between[k][l]
+	// pair the edges with augmenting paths since parallel edges may match in any order
+	pairedWith := make([]int, len(bEdges))
+	for n := range pairedWith {
+		pairedWith[n] = -1
+	}
+	var augment func(n int, seen []bool) bool
+	augment = func(n int, seen []bool) bool {
+		for m, bEdge := range bEdges {
+			if seen[m] || !s.edgeMatch(aEdges[n], bEdge) {
+				continue
+			}
+			seen[m] = true
+			if pairedWith[m] < 0 || augment(pairedWith[m], seen) {
+				pairedWith[m] = n
+				return true
+			}
+		}
+		return false
+	}
+	for n := range aEdges {
+		if !augment(n, make([]bool, len(bEdges))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Isomorphic finds a mapping of the nodes of a onto the nodes of b that preserves every edge, its direction
+// and its multiplicity. A nil nodeMatch or edgeMatch accepts every pair of nodes or edges.
+// Parallel edges may be mapped onto each other in any order.
+// If there is no such mapping then this returns a "not isomorphic" error.
+func Isomorphic(a, b Graph, nodeMatch NodeMatch, edgeMatch EdgeMatch) (Isomorphism, error) {
+	if a.IsDirected() != b.IsDirected() {
+		return nil, ErrNotIsomorphic
+	}
+	aIndex, err := newIsomorphismGraph(a)
+	if err != nil {
+		return nil, err
+	}
+	bIndex, err := newIsomorphismGraph(b)
+	if err != nil {
+		return nil, err
+	}
+	if len(aIndex.nodes) != len(bIndex.nodes) || !sameSignatures(aIndex, bIndex) {
+		return nil, ErrNotIsomorphic
+	}
+
+	state := vf2State{
+		a:         aIndex,
+		b:         bIndex,
+		nodeMatch: nodeMatch,
+		edgeMatch: edgeMatch,
+		order:     matchOrder(aIndex),
+		coreA:     make([]int, len(aIndex.nodes)),
+		coreB:     make([]int, len(bIndex.nodes)),
+		touchedA:  make([]int, len(aIndex.nodes)),
+		touchedB:  make([]int, len(bIndex.nodes)),
+	}
+	for i := range state.coreA {
+		state.coreA[i], state.coreB[i] = -1, -1
+	}
+	if !state.match(0) {
+		return nil, ErrNotIsomorphic
+	}
+	isomorphism := make(Isomorphism)
+	for u, v := range state.coreA {
+		isomorphism[aIndex.nodes[u].GetID()] = bIndex.nodes[v].GetID()
+	}
+	return isomorphism, nil
+}
+
+// sameSignatures compares the degree sequences of a and b, which implies they have as many edges.
+func sameSignatures(a, b isomorphismGraph) bool {
+	sorted := func(ig isomorphismGraph) [][3]int {
+		signatures := append([][3]int{}, ig.signature...)
+		sort.Slice(signatures, func(i, j int) bool {
+			for k := range signatures[i] {
+				if signatures[i][k] != signatures[j][k] {
+					return signatures[i][k] < signatures[j][k]
+				}
+			}
+			return false
+		})
+		return signatures
+	}
+	aSignatures, bSignatures := sorted(a), sorted(b)
+	for i := range aSignatures {
+		if aSignatures[i] != bSignatures[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// WLHash returns a Weisfeiler-Lehman hash of the shape of g that does not depend on its NodeIDs or values.
+// Isomorphic graphs always have the same hash, but it is not a canonical form:
+// some graphs that are not isomorphic share a hash too, such as regular graphs with the same degree and size.
+// Graphs with different hashes are never isomorphic, so the hash can bucket graphs for caches or deduplication
+// as long as Isomorphic confirms that two graphs with the same hash match.
+func WLHash(g Graph) (string, error) {
+	ig, err := newIsomorphismGraph(g)
+	if err != nil {
+		return "", err
+	}
+	labels := make([]string, len(ig.nodes))
+	for i := range labels {
+		labels[i] = fmt.Sprint(ig.signature[i])
+	}
+	distinct := countDistinct(labels)
+	// every round refines the labels until the partition of the nodes stops changing
+	for round := 0; round < len(labels); round++ {
+		refined := make([]string, len(labels))
+		for i := range labels {
+			entries := make([]string, 0, len(ig.neighbors[i]))
+			for _, j := range ig.neighbors[i] {
+				entries = append(entries, fmt.Sprintf("%d>%d<%s", ig.out[i][j], ig.in[i][j], labels[j]))
+			}
+			sort.Strings(entries)
+			refined[i] = hashLabel(labels[i] + "(" + strings.Join(entries, ",") + ")")
+		}
+		labels = refined
+		refinedDistinct := countDistinct(labels)
+		if refinedDistinct == distinct {
+			break
+		}
+		distinct = refinedDistinct
+	}
+
+	sort.Strings(labels)
+	return hashLabel(fmt.Sprintf("%t:%s", g.IsDirected(), strings.Join(labels, ","))), nil
+}
+
+func hashLabel(label string) string {
+	sum := sha256.Sum256([]byte(label))
+	return hex.EncodeToString(sum[:])
+}
+
+func countDistinct(labels []string) int {
+	distinct := make(map[string]bool)
+	for _, label := range labels {
+		distinct[label] = true
+	}
+	return len(distinct)
+}
@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildEdgeList(t *testing.T, bo BuilderOptions, nodes []NodeID, edges [][2]NodeID) Graph {
+	gb := NewGraphBuilder(bo)
+	for _, id := range nodes {
+		gb.AddNode(id)
+	}
+	for _, edge := range edges {
+		gb.AddEdge(edge[0], edge[1])
+	}
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+// assertIsomorphism checks that mapping sends every edge of a to an edge of b.
+func assertIsomorphism(t *testing.T, a, b Graph, mapping Isomorphism) {
+	nodes, err := a.GetNodes()
+	assert.NoError(t, err)
+	assert.Len(t, mapping, len(nodes))
+	edges, err := a.GetEdges()
+	assert.NoError(t, err)
+	for _, edge := range edges {
+		id := edge.GetID()
+		_, err := b.GetEdge(mapping[id.From], mapping[id.To])
+		assert.NoError(t, err, "edge %v is not mapped", id)
+	}
+}
+
+func Test_Isomorphic_Undirected(t *testing.T) {
+	// a hexagon and the same hexagon with its nodes relabeled
+	a := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}, {1, 4}})
+	b := buildEdgeList(t, BuilderOptions{}, []NodeID{10, 20, 30, 40, 50, 60},
+		[][2]NodeID{{30, 10}, {10, 50}, {50, 60}, {60, 20}, {20, 40}, {40, 30}, {60, 30}})
+	mapping, err := Isomorphic(a, b, nil, nil)
+	assert.NoError(t, err)
+	assertIsomorphism(t, a, b, mapping)
+
+	// same number of nodes and edges but 1-4 is now 1-3
+	c := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}, {1, 3}})
+	_, err = Isomorphic(a, c, nil, nil)
+	assert.ErrorIs(t, err, ErrNotIsomorphic)
+}
+
+func Test_Isomorphic_Directed(t *testing.T) {
+	a := buildEdgeList(t, BuilderOptions{IsDirected: true}, []NodeID{1, 2, 3}, [][2]NodeID{{1, 2}, {2, 3}})
+	b := buildEdgeList(t, BuilderOptions{IsDirected: true}, []NodeID{1, 2, 3}, [][2]NodeID{{3, 1}, {1, 2}})
+	mapping, err := Isomorphic(a, b, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Isomorphism{1: 3, 2: 1, 3: 2}, mapping)
+
+	// the middle node of a has one edge in and one edge out, the middle node of c has two edges out
+	c := buildEdgeList(t, BuilderOptions{IsDirected: true}, []NodeID{1, 2, 3}, [][2]NodeID{{2, 1}, {2, 3}})
+	_, err = Isomorphic(a, c, nil, nil)
+	assert.ErrorIs(t, err, ErrNotIsomorphic)
+
+	undirected := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3}, [][2]NodeID{{1, 2}, {2, 3}})
+	_, err = Isomorphic(a, undirected, nil, nil)
+	assert.ErrorIs(t, err, ErrNotIsomorphic)
+}
+
+func Test_Isomorphic_Regular(t *testing.T) {
+	// a 6-cycle and two triangles are both 2-regular
+	cycle := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}})
+	triangles := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 1}, {4, 5}, {5, 6}, {6, 4}})
+	_, err := Isomorphic(cycle, triangles, nil, nil)
+	assert.ErrorIs(t, err, ErrNotIsomorphic)
+}
+
+func Test_Isomorphic_Match(t *testing.T) {
+	build := func(colors map[NodeID]string, weights map[[2]NodeID][]int) Graph {
+		gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true, AllowRedundantEdges: true})
+		for id, color := range colors {
+			gb.AddNode(id, color)
+		}
+		for edge, values := range weights {
+			for _, value := range values {
+				gb.AddEdge(edge[0], edge[1], value)
+			}
+		}
+		graph, err := gb.Build()
+		assert.NoError(t, err)
+		return graph
+	}
+	sameValue := func(a, b interface{ GetValue() (interface{}, error) }) bool {
+		aValue, aErr := a.GetValue()
+		bValue, bErr := b.GetValue()
+		return aErr == nil && bErr == nil && aValue == bValue
+	}
+	nodeMatch := func(a Node, b Node) bool { return sameValue(a, b) }
+	edgeMatch := func(a Edge, b Edge) bool { return sameValue(a, b) }
+
+	a := build(map[NodeID]string{1: "red", 2: "blue", 3: "blue"},
+		map[[2]NodeID][]int{{1, 2}: {1, 2}, {2, 3}: {3}, {1, 3}: {4}, {3, 3}: {5}})
+	b := build(map[NodeID]string{1: "blue", 2: "blue", 3: "red"},
+		map[[2]NodeID][]int{{3, 2}: {2, 1}, {1, 2}: {3}, {3, 1}: {4}, {1, 1}: {5}})
+	mapping, err := Isomorphic(a, b, nodeMatch, edgeMatch)
+	assert.NoError(t, err)
+	assert.Equal(t, Isomorphism{1: 3, 2: 2, 3: 1}, mapping)
+
+	// the shape still matches without the matchers but the values do not
+	c := build(map[NodeID]string{1: "blue", 2: "blue", 3: "red"},
+		map[[2]NodeID][]int{{3, 2}: {2, 1}, {1, 2}: {3}, {3, 1}: {4}, {1, 1}: {6}})
+	_, err = Isomorphic(a, c, nil, nil)
+	assert.NoError(t, err)
+	_, err = Isomorphic(a, c, nodeMatch, edgeMatch)
+	assert.ErrorIs(t, err, ErrNotIsomorphic)
+	_, err = Isomorphic(a, c, nodeMatch, nil)
+	assert.NoError(t, err)
+}
+
+func Test_WLHash(t *testing.T) {
+	a := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}, {1, 4}})
+	b := buildEdgeList(t, BuilderOptions{}, []NodeID{10, 20, 30, 40, 50, 60},
+		[][2]NodeID{{30, 10}, {10, 50}, {50, 60}, {60, 20}, {20, 40}, {40, 30}, {60, 30}})
+	c := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}, {1, 3}})
+	directed := buildEdgeList(t, BuilderOptions{IsDirected: true}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}, {1, 4}})
+	hash := func(g Graph) string {
+		actual_hash, err := WLHash(g)
+		assert.NoError(t, err)
+		return actual_hash
+	}
+	assert.Equal(t, hash(a), hash(b))
+	assert.NotEqual(t, hash(a), hash(c))
+	assert.NotEqual(t, hash(a), hash(directed))
+
+	// a 6-cycle and two triangles are both 2-regular, so they collide
+	cycle := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}, {6, 1}})
+	triangles := buildEdgeList(t, BuilderOptions{}, []NodeID{1, 2, 3, 4, 5, 6},
+		[][2]NodeID{{1, 2}, {2, 3}, {3, 1}, {4, 5}, {5, 6}, {6, 4}})
+	assert.Equal(t, hash(cycle), hash(triangles))
+	_, err := Isomorphic(cycle, triangles, nil, nil)
+	assert.ErrorIs(t, err, ErrNotIsomorphic)
+
+	empty, err := NewGraphBuilder().Build()
+	assert.NoError(t, err)
+	assert.Len(t, hash(empty), 64)
+}
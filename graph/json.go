@@ -0,0 +1,147 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ValueDecoder turns the JSON encoding of a node or edge value back into the value.
+type ValueDecoder func(data json.RawMessage) (interface{}, error)
+
+type jsonGraph struct {
+	Directed bool       `json:"directed"`
+	Nodes    []jsonNode `json:"nodes"`
+	Edges    []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	ID    NodeID          `json:"id"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type jsonEdge struct {
+	From  NodeID          `json:"from"`
+	To    NodeID          `json:"to"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// MarshalJSON encodes an untyped graph as JSON. See MarshalTypedJSON.
+func MarshalJSON(g Graph) ([]byte, error) {
+	return MarshalTypedJSON(g)
+}
+
+// MarshalTypedJSON encodes whether g is directed along with its nodes and edges sorted by id.
+// Values are encoded with encoding/json and nodes or edges without a value have no "value" field.
+// Parallel edges are encoded in index order, so they keep their order but not gaps in their indices.
+func MarshalTypedJSON[N, E any](g TypedGraph[N, E]) ([]byte, error) {
+	encoded := jsonGraph{
+		Directed: g.IsDirected(),
+		Nodes:    make([]jsonNode, 0),
+		Edges:    make([]jsonEdge, 0),
+	}
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		value, err := encodeJSONValue(node.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		encoded.Nodes = append(encoded.Nodes, jsonNode{ID: node.GetID(), Value: value})
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range edges {
+		value, err := encodeJSONValue(edge.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		encoded.Edges = append(encoded.Edges, jsonEdge{From: edge.GetID().From, To: edge.GetID().To, Value: value})
+	}
+	return json.Marshal(encoded)
+}
+
+// encodeJSONValue encodes the result of GetValue, which is nil if there is no value.
+func encodeJSONValue[T any](value T, err error) (json.RawMessage, error) {
+	var noNodeValue NoValueFoundInNodeError
+	var noEdgeValue NoValueFoundInEdgeError
+	if errors.As(err, &noNodeValue) || errors.As(err, &noEdgeValue) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// UnmarshalJSON decodes a graph encoded by MarshalJSON.
+// Values are decoded with decodeValue, or into the types chosen by encoding/json if it is nil.
+// See UnmarshalTypedJSON.
+func UnmarshalJSON(data []byte, decodeValue ValueDecoder, bo ...BuilderOptions) (Graph, error) {
+	decode := func(raw json.RawMessage) (interface{}, error) {
+		if decodeValue != nil {
+			return decodeValue(raw)
+		}
+		var value interface{}
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	}
+	return unmarshalGraph(data, decode, decode, bo...)
+}
+
+// UnmarshalTypedJSON decodes a graph encoded by MarshalTypedJSON with encoding/json decoding its values.
+// The graph is rebuilt with a graph builder using the given options, so the same checks are made as when it was built;
+// for example, loading a graph with parallel edges requires AllowParallelEdges.
+// IsDirected is always taken from the data.
+func UnmarshalTypedJSON[N, E any](data []byte, bo ...BuilderOptions) (TypedGraph[N, E], error) {
+	decodeNode := func(raw json.RawMessage) (N, error) {
+		var value N
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	}
+	decodeEdge := func(raw json.RawMessage) (E, error) {
+		var value E
+		err := json.Unmarshal(raw, &value)
+		return value, err
+	}
+	return unmarshalGraph(data, decodeNode, decodeEdge, bo...)
+}
+
+func unmarshalGraph[N, E any](data []byte, decodeNode func(json.RawMessage) (N, error), decodeEdge func(json.RawMessage) (E, error), bo ...BuilderOptions) (TypedGraph[N, E], error) {
+	var decoded jsonGraph
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	builderOptions := BuilderOptions{}
+	if len(bo) == 1 {
+		builderOptions = bo[0]
+	}
+	builderOptions.IsDirected = decoded.Directed
+	gb := NewTypedGraphBuilder[N, E](builderOptions)
+	for _, node := range decoded.Nodes {
+		if node.Value == nil {
+			gb.AddNode(node.ID)
+			continue
+		}
+		value, err := decodeNode(node.Value)
+		if err != nil {
+			return nil, err
+		}
+		gb.AddNode(node.ID, value)
+	}
+	for _, edge := range decoded.Edges {
+		if edge.Value == nil {
+			gb.AddEdge(edge.From, edge.To)
+			continue
+		}
+		value, err := decodeEdge(edge.Value)
+		if err != nil {
+			return nil, err
+		}
+		gb.AddEdge(edge.From, edge.To, value)
+	}
+	return gb.Build()
+}
@@ -0,0 +1,109 @@
+package graph
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MarshalJSON(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(2, "b")
+	gb.AddNode(1)
+	gb.AddEdge(2, 1, 1.5)
+	gb.AddEdge(1, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_data, err := MarshalJSON(graph)
+	assert.NoError(t, err)
+	expected_data := `{"directed":true,"nodes":[{"id":1},{"id":2,"value":"b"}],"edges":[{"from":1,"to":2},{"from":2,"to":1,"value":1.5}]}`
+	assert.JSONEq(t, expected_data, string(actual_data))
+
+	actual_graph, err := UnmarshalJSON(actual_data, nil)
+	assert.NoError(t, err)
+	AssertGraphEquals(t, graph, actual_graph)
+}
+
+func Test_UnmarshalJSON_Undirected(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 4; i++ {
+		gb.AddNode(NodeID(i), float64(i))
+	}
+	gb.AddEdge(1, 2, "x")
+	gb.AddEdge(3, 2, "y")
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	data, err := MarshalJSON(graph)
+	assert.NoError(t, err)
+	actual_graph, err := UnmarshalJSON(data, nil)
+	assert.NoError(t, err)
+	assert.False(t, actual_graph.IsDirected())
+	AssertGraphEquals(t, graph, actual_graph)
+}
+
+func Test_UnmarshalJSON_Decoder(t *testing.T) {
+	data := []byte(`{"directed":false,"nodes":[{"id":1,"value":"7"},{"id":2,"value":"8"}],"edges":[{"from":1,"to":2,"value":"9"}]}`)
+	actual_graph, err := UnmarshalJSON(data, func(raw json.RawMessage) (interface{}, error) {
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, err
+		}
+		return strconv.Atoi(text)
+	})
+	assert.NoError(t, err)
+	edge, err := actual_graph.GetEdge(2, 1)
+	assert.NoError(t, err)
+	value, err := edge.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 9, value)
+}
+
+func Test_UnmarshalJSON_Validation(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true, AllowRedundantEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "first")
+	gb.AddEdge(1, 2, "second")
+	gb.AddEdge(2, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	data, err := MarshalJSON(graph)
+	assert.NoError(t, err)
+
+	_, err = UnmarshalJSON(data, nil)
+	assert.ErrorIs(t, err, DuplicateEdgeError{FromID: 1, ToID: 2})
+
+	actual_graph, err := UnmarshalJSON(data, nil, BuilderOptions{AllowParallelEdges: true, AllowRedundantEdges: true})
+	assert.NoError(t, err)
+	AssertGraphEquals(t, graph, actual_graph)
+
+	_, err = UnmarshalJSON([]byte(`{"nodes":[{"id":1}],"edges":[{"from":1,"to":3}]}`), nil)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 3})
+	_, err = UnmarshalJSON([]byte(`{"nodes":`), nil)
+	assert.Error(t, err)
+}
+
+func Test_UnmarshalTypedJSON(t *testing.T) {
+	type territory struct {
+		Name string
+		Army int
+	}
+	gb := NewTypedGraphBuilder[territory, int](BuilderOptions{IsDirected: true})
+	gb.AddNode(1, territory{Name: "north", Army: 3})
+	gb.AddNode(2, territory{Name: "south", Army: 5})
+	gb.AddEdge(1, 2, 4)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	data, err := MarshalTypedJSON(graph)
+	assert.NoError(t, err)
+	actual_graph, err := UnmarshalTypedJSON[territory, int](data)
+	assert.NoError(t, err)
+	AssertGraphEquals(t, Untyped(graph), Untyped(actual_graph))
+	node, err := actual_graph.GetNode(2)
+	assert.NoError(t, err)
+	value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, territory{Name: "south", Army: 5}, value)
+}
@@ -0,0 +1,148 @@
+package graph
+
+// KeyedGraphBuilder is a TypedKeyedGraphBuilder for a graph with untyped node and edge values.
+// Unlike GraphBuilder it cannot be a type alias because it keeps the type parameter K,
+// and Go does not allow aliases of generic types, so it is an interface embedding TypedKeyedGraphBuilder.
+// Any TypedKeyedGraphBuilder[K, interface{}, interface{}] implements it.
+type KeyedGraphBuilder[K comparable] interface {
+	TypedKeyedGraphBuilder[K, interface{}, interface{}]
+}
+
+// TypedKeyedGraphBuilder is like TypedGraphBuilder but identifies nodes with keys of type K instead of NodeIDs.
+// Every key is given a NodeID the first time AddNode or AddEdge is called with it, counting up from 0.
+// Build returns a KeyNotFoundError for keys that are used by AddEdge but never added.
+// Other errors still name nodes by the NodeIDs given to their keys.
+type TypedKeyedGraphBuilder[K comparable, N, E any] interface {
+	// AddNode adds a node identified by key, like TypedGraphBuilder.AddNode.
+	AddNode(key K, value ...N)
+
+	// AddEdge adds an edge connecting the nodes identified by from and to, like TypedGraphBuilder.AddEdge.
+	AddEdge(from K, to K, value ...E)
+
+	// Build creates a graph that can look up its nodes by key.
+	Build() (TypedKeyedGraph[K, N, E], error)
+}
+
+// KeyedGraph is a TypedKeyedGraph with untyped node and edge values.
+// Like KeyedGraphBuilder, it is an interface embedding TypedKeyedGraph rather than a type alias
+// because it keeps the type parameter K. Any TypedKeyedGraph[K, interface{}, interface{}] implements it.
+type KeyedGraph[K comparable] interface {
+	TypedKeyedGraph[K, interface{}, interface{}]
+}
+
+// TypedKeyedGraph is a graph built by a TypedKeyedGraphBuilder.
+// It can be used as a TypedGraph, and converts between the keys of its nodes and their NodeIDs.
+type TypedKeyedGraph[K comparable, N, E any] interface {
+	TypedGraph[N, E]
+
+	// NodeByKey returns the node identified by key.
+	NodeByKey(key K) (TypedNode[N, E], error)
+
+	// IDOf returns the NodeID given to key.
+	IDOf(key K) (NodeID, error)
+
+	// KeyOf returns the key of the node with the given id.
+	KeyOf(id NodeID) (K, error)
+}
+
+type keyedGraphBuilder[K comparable, N, E any] struct {
+	builder TypedGraphBuilder[N, E]
+	ids     map[K]NodeID
+	keys    []K
+}
+
+// NewKeyedGraphBuilder creates a builder for a graph with untyped node and edge values
+// whose nodes are identified by keys of type K.
+func NewKeyedGraphBuilder[K comparable](bo ...BuilderOptions) KeyedGraphBuilder[K] {
+	return NewTypedKeyedGraphBuilder[K, interface{}, interface{}](bo...)
+}
+
+// NewTypedKeyedGraphBuilder creates a builder for a graph whose nodes are identified by keys of type K
+// and store values of type N, and whose edges store values of type E.
+func NewTypedKeyedGraphBuilder[K comparable, N, E any](bo ...BuilderOptions) TypedKeyedGraphBuilder[K, N, E] {
+	return &keyedGraphBuilder[K, N, E]{
+		builder: NewTypedGraphBuilder[N, E](bo...),
+		ids:     make(map[K]NodeID),
+		keys:    make([]K, 0),
+	}
+}
+
+// idOf returns the NodeID of key, giving it the next one if it has none yet.
+func (kb *keyedGraphBuilder[K, N, E]) idOf(key K) NodeID {
+	if id, exists := kb.ids[key]; exists {
+		return id
+	}
+	id := NodeID(len(kb.keys))
+	kb.ids[key] = id
+	kb.keys = append(kb.keys, key)
+	return id
+}
+
+func (kb *keyedGraphBuilder[K, N, E]) AddNode(key K, value ...N) {
+	kb.builder.AddNode(kb.idOf(key), value...)
+}
+
+func (kb *keyedGraphBuilder[K, N, E]) AddEdge(from K, to K, value ...E) {
+	kb.builder.AddEdge(kb.idOf(from), kb.idOf(to), value...)
+}
+
+func (kb *keyedGraphBuilder[K, N, E]) Build() (TypedKeyedGraph[K, N, E], error) {
+	graph, err := kb.builder.Build()
+	if err != nil {
+		return nil, kb.keyError(err)
+	}
+	ids := make(map[K]NodeID, len(kb.ids))
+	for key, id := range kb.ids {
+		ids[key] = id
+	}
+	return keyedGraph[K, N, E]{
+		TypedGraph: graph,
+		ids:        ids,
+		keys:       append([]K{}, kb.keys...),
+	}, nil
+}
+
+// keyError replaces the NodeNotFoundErrors in err with KeyNotFoundErrors.
+func (kb *keyedGraphBuilder[K, N, E]) keyError(err error) error {
+	switch e := err.(type) {
+	case NodeNotFoundError:
+		return KeyNotFoundError[K]{Key: kb.keys[e.NodeID]}
+	case BuilderError:
+		calls := make([]CallError, 0, len(e.Calls))
+		for _, call := range e.Calls {
+			calls = append(calls, CallError{Index: call.Index, Err: kb.keyError(call.Err)})
+		}
+		return BuilderError{Calls: calls}
+	}
+	return err
+}
+
+type keyedGraph[K comparable, N, E any] struct {
+	TypedGraph[N, E]
+	ids  map[K]NodeID
+	keys []K
+}
+
+func (kg keyedGraph[K, N, E]) NodeByKey(key K) (TypedNode[N, E], error) {
+	id, err := kg.IDOf(key)
+	if err != nil {
+		return nil, err
+	}
+	return kg.GetNode(id)
+}
+
+func (kg keyedGraph[K, N, E]) IDOf(key K) (NodeID, error) {
+	id, exists := kg.ids[key]
+	if !exists {
+		return 0, KeyNotFoundError[K]{Key: key}
+	}
+	return id, nil
+}
+
+func (kg keyedGraph[K, N, E]) KeyOf(id NodeID) (K, error) {
+	if id < 0 || int(id) >= len(kg.keys) {
+		var key K
+		return key, NodeNotFoundError{NodeID: id}
+	}
+	return kg.keys[id], nil
+}
@@ -0,0 +1,98 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_KeyedGraphBuilder(t *testing.T) {
+	gb := NewKeyedGraphBuilder[string]()
+	gb.AddNode("Alaska", 3)
+	gb.AddNode("Kamchatka", 2)
+	gb.AddNode("Alberta")
+	gb.AddEdge("Alaska", "Kamchatka", 4.0)
+	gb.AddEdge("Alaska", "Alberta", 1.0)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	node, err := graph.NodeByKey("Kamchatka")
+	assert.NoError(t, err)
+	assert.Equal(t, NodeID(1), node.GetID())
+	actual_value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, actual_value)
+	edges, err := node.GetIncidentEdges()
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 0, To: 1}}, edgeIDs(edges))
+
+	actual_id, err := graph.IDOf("Alberta")
+	assert.NoError(t, err)
+	assert.Equal(t, NodeID(2), actual_id)
+	actual_key, err := graph.KeyOf(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Alaska", actual_key)
+
+	_, err = graph.NodeByKey("Yakutsk")
+	assert.ErrorIs(t, err, KeyNotFoundError[string]{Key: "Yakutsk"})
+	_, err = graph.IDOf("Yakutsk")
+	assert.ErrorIs(t, err, KeyNotFoundError[string]{Key: "Yakutsk"})
+	_, err = graph.KeyOf(3)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 3})
+	_, err = graph.KeyOf(-1)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: -1})
+
+	// a keyed graph can be searched like any other graph
+	path, err := ShortestPath(graph, 1, 2, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, path.Cost)
+}
+
+func Test_TypedKeyedGraphBuilder(t *testing.T) {
+	type corner struct {
+		q, r      int
+		direction string
+	}
+	gb := NewTypedKeyedGraphBuilder[corner, struct{}, float64](BuilderOptions{IsDirected: true, Storage: CSRStorage})
+	gb.AddEdge(corner{0, 0, "N"}, corner{0, 0, "NE"}, 30)
+	gb.AddEdge(corner{0, 0, "NE"}, corner{1, -1, "S"}, 90)
+	gb.AddNode(corner{0, 0, "N"})
+	gb.AddNode(corner{0, 0, "NE"})
+	gb.AddNode(corner{1, -1, "S"})
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	node, err := graph.NodeByKey(corner{0, 0, "NE"})
+	assert.NoError(t, err)
+	edges, err := node.GetOutgoingEdges()
+	assert.NoError(t, err)
+	assert.Len(t, edges, 1)
+	to, err := edges[0].GetTo()
+	assert.NoError(t, err)
+	actual_key, err := graph.KeyOf(to.GetID())
+	assert.NoError(t, err)
+	assert.Equal(t, corner{1, -1, "S"}, actual_key)
+	actual_value, err := edges[0].GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 90.0, actual_value)
+}
+
+func Test_KeyedGraphBuilder_Errors(t *testing.T) {
+	gb := NewKeyedGraphBuilder[string]()
+	gb.AddNode("Alaska")
+	gb.AddEdge("Alaska", "Yakutsk")
+	_, err := gb.Build()
+	assert.ErrorIs(t, err, KeyNotFoundError[string]{Key: "Yakutsk"})
+
+	gb = NewKeyedGraphBuilder[string](BuilderOptions{CollectAllErrors: true})
+	gb.AddNode("Alaska")
+	gb.AddNode("Alaska")
+	gb.AddEdge("Yakutsk", "Alaska")
+	gb.AddEdge("Alaska", "Alaska")
+	_, err = gb.Build()
+	assert.Equal(t, BuilderError{Calls: []CallError{
+		{Index: 1, Err: DuplicateNodeError{NodeID: 0}},
+		{Index: 2, Err: KeyNotFoundError[string]{Key: "Yakutsk"}},
+		{Index: 3, Err: RedundantEdgeError{NodeID: 0}},
+	}}, err)
+}
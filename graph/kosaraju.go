@@ -4,41 +4,52 @@ import "sort"
 
 type StronglyConnectedComponent []Node
 
-func Kosaraju(g Graph) []StronglyConnectedComponent {
+func Kosaraju(g Graph) ([]StronglyConnectedComponent, error) {
 	sccs := make([]StronglyConnectedComponent, 0)
 	L := make([]Node, 0)
-	DepthFirstSearch(g, DepthFirstSearchOptions{
-		AfterRecursion: func(n Node) {
+	_, err := DepthFirstSearch(g, DepthFirstSearchOptions{
+		AfterRecursion: func(n Node) (TraversalControl, error) {
 			L = append([]Node{n}, L...)
+			return Continue, nil
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	scc := make(StronglyConnectedComponent, 0)
-	DepthFirstSearch(g, DepthFirstSearchOptions{
+	_, err = DepthFirstSearch(g, DepthFirstSearchOptions{
 		CustomOrder:  L,
 		ReverseGraph: true,
-		BeforeRecursion: func(n Node) {
+		BeforeRecursion: func(n Node) (TraversalControl, error) {
 			scc = append(scc, n)
+			return Continue, nil
 		},
-		AfterSearch: func(n Node) {
+		AfterSearch: func(n Node) (TraversalControl, error) {
 			if len(scc) > 0 {
 				sccs = append(sccs, scc)
 			}
 			scc = make([]Node, 0)
+			return Continue, nil
 		},
 	})
-	sortSCCs(sccs)
-	return sccs
+	if err != nil {
+		return nil, err
+	}
+	sortComponents(sccs)
+	return sccs, nil
 }
 
-func sortSCCs(sccs []StronglyConnectedComponent) {
-	for _, scc := range sccs {
-		sortNodes(scc)
+// sortComponents sorts the nodes of every component by id
+// and then the components by size and by their smallest id.
+func sortComponents[C ~[]Node](components []C) {
+	for _, component := range components {
+		sortNodes(component)
 	}
-	sort.Slice(sccs, func(i, j int) bool {
-		if len(sccs[i]) != len(sccs[j]) {
-			return len(sccs[i]) < len(sccs[j])
+	sort.Slice(components, func(i, j int) bool {
+		if len(components[i]) != len(components[j]) {
+			return len(components[i]) < len(components[j])
 		}
-		return sccs[i][0].GetID() < sccs[j][0].GetID()
+		return components[i][0].GetID() < components[j][0].GetID()
 	})
 }
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -50,7 +51,8 @@ func Test_Kosaraju_Directed(t *testing.T) {
 	graph, err := gb.Build()
 	assert.NoError(t, err)
 
-	actual_sccs := Kosaraju(graph)
+	actual_sccs, err := Kosaraju(graph)
+	assert.NoError(t, err)
 	expected_sccs := []StronglyConnectedComponent{
 		{rawDirectedNode{ID: 8, Incoming: []NodeID{7}, Outgoing: []NodeID{}}},
 		{
@@ -77,7 +79,8 @@ func Test_Kosaraju_Undirected(t *testing.T) {
 	gb.AddEdge(3, 4)
 	graph, err := gb.Build()
 	assert.NoError(t, err)
-	actual_sccs := Kosaraju(graph)
+	actual_sccs, err := Kosaraju(graph)
+	assert.NoError(t, err)
 	expected_sccs := []StronglyConnectedComponent{
 		{
 			rawUndirectedNode{ID: 1, Neighbors: []NodeID{2}},
@@ -90,3 +93,13 @@ func Test_Kosaraju_Undirected(t *testing.T) {
 	}
 	AssertSCCsEquals(t, expected_sccs, actual_sccs)
 }
+
+func Test_Kosaraju_Error(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	expected_error := errors.New("nodes unavailable")
+	_, err = Kosaraju(failingGraph{Graph: graph, err: expected_error})
+	assert.ErrorIs(t, err, expected_error)
+}
@@ -0,0 +1,130 @@
+package graph
+
+import "sort"
+
+// EdgeFilter reports whether an edge may be used.
+type EdgeFilter func(Edge) (bool, error)
+
+// NodeBlocker reports whether a node blocks trails. A trail may start or end at a blocked node
+// but never pass through it.
+type NodeBlocker func(Node) (bool, error)
+
+// Trail is a walk through a graph that uses every edge at most once.
+type Trail struct {
+	// Nodes are the nodes in the order they are visited, so a node may appear more than once.
+	Nodes []Node
+	// Edges are the edges in the order they are followed, so Edges[i] joins Nodes[i] and Nodes[i+1].
+	Edges []Edge
+	// Length is the number of edges in the trail.
+	Length int
+}
+
+// longestTrailSearch is the state of the exhaustive search behind LongestTrail.
+type longestTrailSearch struct {
+	g        Graph
+	adjacent map[NodeID][]adjacentEdge
+	blocked  map[NodeID]bool
+	used     map[EdgeID]bool
+	nodes    []NodeID
+	edges    []Edge
+	longest  Trail
+}
+
+func (s *longestTrailSearch) extend(current NodeID) error {
+	if len(s.edges) > s.longest.Length {
+		s.longest = Trail{Nodes: make([]Node, 0, len(s.nodes)), Edges: append([]Edge{}, s.edges...), Length: len(s.edges)}
+		for _, id := range s.nodes {
+			node, err := s.g.GetNode(id)
+			if err != nil {
+				return err
+			}
+			s.longest.Nodes = append(s.longest.Nodes, node)
+		}
+	}
+	if len(s.edges) > 0 && s.blocked[current] {
+		return nil
+	}
+	for _, next := range s.adjacent[current] {
+		id := next.Edge.GetID()
+		if s.used[id] {
+			continue
+		}
+		s.used[id] = true
+		s.nodes = append(s.nodes, next.To)
+		s.edges = append(s.edges, next.Edge)
+		if err := s.extend(next.To); err != nil {
+			return err
+		}
+		s.nodes = s.nodes[:len(s.nodes)-1]
+		s.edges = s.edges[:len(s.edges)-1]
+		s.used[id] = false
+	}
+	return nil
+}
+
+// LongestTrail finds the longest trail in an undirected graph that only uses edges accepted by filter
+// and does not pass through nodes rejected by blocker. A nil filter accepts every edge
+// and a nil blocker blocks no node.
+// The search tries every trail, so it is only meant for small sets of edges.
+// Among trails of the same length it returns the first one found when starting from the smallest ids
+// and following edges in sorted order. If no edge can be used, the trail is empty.
+func LongestTrail(g Graph, filter EdgeFilter, blocker NodeBlocker) (Trail, error) {
+	if g.IsDirected() {
+		return Trail{}, CannotUseForDirectedGraphError{MethodName: "LongestTrail"}
+	}
+	s := &longestTrailSearch{
+		g:        g,
+		adjacent: make(map[NodeID][]adjacentEdge),
+		blocked:  make(map[NodeID]bool),
+		used:     make(map[EdgeID]bool),
+		longest:  Trail{Nodes: make([]Node, 0), Edges: make([]Edge, 0)},
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return Trail{}, err
+	}
+	for _, edge := range edges {
+		if filter != nil {
+			accepted, err := filter(edge)
+			if err != nil {
+				return Trail{}, err
+			}
+			if !accepted {
+				continue
+			}
+		}
+		id := edge.GetID()
+		s.adjacent[id.From] = append(s.adjacent[id.From], adjacentEdge{Edge: edge, To: id.To})
+		if id.From != id.To {
+			s.adjacent[id.To] = append(s.adjacent[id.To], adjacentEdge{Edge: edge, To: id.From})
+		}
+	}
+
+	starts := make([]NodeID, 0, len(s.adjacent))
+	for id := range s.adjacent {
+		starts = append(starts, id)
+	}
+	sortNodeIDs(starts)
+	for _, id := range starts {
+		sort.Slice(s.adjacent[id], func(i, j int) bool {
+			return lessEdgeID(s.adjacent[id][i].Edge.GetID(), s.adjacent[id][j].Edge.GetID())
+		})
+		if blocker != nil {
+			node, err := g.GetNode(id)
+			if err != nil {
+				return Trail{}, err
+			}
+			s.blocked[id], err = blocker(node)
+			if err != nil {
+				return Trail{}, err
+			}
+		}
+	}
+	for _, id := range starts {
+		s.nodes = []NodeID{id}
+		if err := s.extend(id); err != nil {
+			return Trail{}, err
+		}
+	}
+	return s.longest, nil
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func trailEdgeIDs(trail Trail) []EdgeID {
+	ids := make([]EdgeID, 0)
+	for _, edge := range trail.Edges {
+		ids = append(ids, edge.GetID())
+	}
+	return ids
+}
+
+func Test_LongestTrail_Cycle(t *testing.T) {
+	// triangle 1, 2, 3 with the tail 3 - 4, so the longest trail has to start at 3 and end at 4
+	gb := NewGraphBuilder()
+	for i := 1; i < 5; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(1, 3)
+	gb.AddEdge(3, 4)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_trail, err := LongestTrail(graph, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, actual_trail.Length)
+	assert.Equal(t, []NodeID{3, 1, 2, 3, 4}, nodeIDs(actual_trail.Nodes))
+	assert.Equal(t, []EdgeID{{From: 1, To: 3}, {From: 1, To: 2}, {From: 2, To: 3}, {From: 3, To: 4}}, trailEdgeIDs(actual_trail))
+}
+
+func Test_LongestTrail_FilterAndBlocker(t *testing.T) {
+	// path 1 - 2 - 3 - 4 - 5 with the branch 3 - 6 and the edges 5 - 7 - 8 of another player
+	gb := NewGraphBuilder()
+	for i := 1; i < 9; i++ {
+		gb.AddNode(NodeID(i), i == 4)
+	}
+	gb.AddEdge(1, 2, "blue")
+	gb.AddEdge(2, 3, "blue")
+	gb.AddEdge(3, 4, "blue")
+	gb.AddEdge(4, 5, "blue")
+	gb.AddEdge(3, 6, "blue")
+	gb.AddEdge(5, 7, "red")
+	gb.AddEdge(7, 8, "red")
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	isBlue := func(e Edge) (bool, error) {
+		value, err := e.GetValue()
+		return value == "blue", err
+	}
+	isBlocked := func(n Node) (bool, error) {
+		value, err := n.GetValue()
+		if err != nil {
+			return false, err
+		}
+		return value.(bool), nil
+	}
+
+	actual_trail, err := LongestTrail(graph, isBlue, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, actual_trail.Length)
+	assert.Equal(t, []NodeID{1, 2, 3, 4, 5}, nodeIDs(actual_trail.Nodes))
+
+	// trails may end at the blocked node 4 but not pass through it
+	actual_trail, err = LongestTrail(graph, isBlue, isBlocked)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, actual_trail.Length)
+	assert.Equal(t, []NodeID{1, 2, 3, 4}, nodeIDs(actual_trail.Nodes))
+
+	actual_trail, err = LongestTrail(graph, func(e Edge) (bool, error) {
+		return false, nil
+	}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, actual_trail.Length)
+	assert.Empty(t, actual_trail.Edges)
+}
+
+func Test_LongestTrail_Directed(t *testing.T) {
+	graph := buildWeightedGraph(t, BuilderOptions{IsDirected: true})
+	_, err := LongestTrail(graph, nil, nil)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "LongestTrail"})
+}
@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"math"
+	"sort"
+)
+
+// CapacityFunc returns how much flow an edge can carry, usually read from the edge's GetValue.
+// Capacities must be finite and not negative.
+type CapacityFunc func(Edge) (float64, error)
+
+// flowEpsilon is the amount of residual capacity below which an edge is treated as saturated,
+// so rounding errors do not keep the search going.
+const flowEpsilon = 1e-9
+
+// FlowResult is a maximum flow through a directed graph along with a minimum cut.
+type FlowResult struct {
+	// Value is the total amount of flow leaving the source, which equals the capacity of the minimum cut.
+	Value float64
+	// EdgeFlows maps the id of every edge to the flow going through it.
+	EdgeFlows map[EdgeID]float64
+	// SourceSide holds the sorted ids of the nodes that can still be reached from the source
+	// through edges with capacity left. SinkSide holds the sorted ids of all other nodes.
+	SourceSide []NodeID
+	SinkSide   []NodeID
+	// CutEdges are the edges from the source side to the sink side sorted by id.
+	// They are all saturated and removing them disconnects the sink from the source.
+	CutEdges []Edge
+}
+
+// flowArc is an edge of the residual network. Arcs are stored in pairs,
+// so the arc going the other way is always at the index with the last bit flipped.
+type flowArc struct {
+	to       int
+	capacity float64
+	flow     float64
+}
+
+// flowNetwork is the residual network of a graph with nodes numbered in ascending id order.
+type flowNetwork struct {
+	arcs      []flowArc
+	adjacency [][]int
+	levels    []int
+	next      []int
+}
+
+func (fn *flowNetwork) residual(arc int) float64 {
+	return fn.arcs[arc].capacity - fn.arcs[arc].flow
+}
+
+// buildLevels numbers the nodes by their distance from the source through arcs with capacity left
+// and reports whether the sink can be reached.
+func (fn *flowNetwork) buildLevels(source, sink int) bool {
+	for i := range fn.levels {
+		fn.levels[i] = -1
+	}
+	fn.levels[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, arc := range fn.adjacency[current] {
+			to := fn.arcs[arc].to
+			if fn.levels[to] < 0 && fn.residual(arc) > flowEpsilon {
+				fn.levels[to] = fn.levels[current] + 1
+				queue = append(queue, to)
+			}
+		}
+	}
+	return fn.levels[sink] >= 0
+}
+
+// augment pushes up to limit flow from current to the sink along arcs that go one level further each step.
+func (fn *flowNetwork) augment(current, sink int, limit float64) float64 {
+	if current == sink {
+		return limit
+	}
+	for ; fn.next[current] < len(fn.adjacency[current]); fn.next[current]++ {
+		arc := fn.adjacency[current][fn.next[current]]
+		to := fn.arcs[arc].to
+		if fn.levels[to] != fn.levels[current]+1 || fn.residual(arc) <= flowEpsilon {
+			continue
+		}
+		pushed := fn.augment(to, sink, math.Min(limit, fn.residual(arc)))
+		if pushed > flowEpsilon {
+			fn.arcs[arc].flow += pushed
+			fn.arcs[arc^1].flow -= pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// MaxFlow finds the largest amount of flow that can go from source to sink in a directed graph
+// without any edge carrying more than its capacity, using Dinic's algorithm.
+// It also returns the minimum cut separating the sink from the source.
+func MaxFlow(g Graph, source NodeID, sink NodeID, capacity CapacityFunc) (FlowResult, error) {
+	if !g.IsDirected() {
+		return FlowResult{}, CannotUseForUndirectedGraphError{MethodName: "MaxFlow"}
+	}
+	if _, err := g.GetNode(source); err != nil {
+		return FlowResult{}, err
+	}
+	if _, err := g.GetNode(sink); err != nil {
+		return FlowResult{}, err
+	}
+	if source == sink {
+		return FlowResult{}, SourceIsSinkError{NodeID: source}
+	}
+	allNodes, err := g.GetNodes()
+	if err != nil {
+		return FlowResult{}, err
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return FlowResult{}, err
+	}
+
+	indices := make(map[NodeID]int)
+	for i, node := range allNodes {
+		indices[node.GetID()] = i
+	}
+	fn := &flowNetwork{
+		arcs:      make([]flowArc, 0, 2*len(edges)),
+		adjacency: make([][]int, len(allNodes)),
+		levels:    make([]int, len(allNodes)),
+		next:      make([]int, len(allNodes)),
+	}
+	for _, edge := range edges {
+		id := edge.GetID()
+		edgeCapacity, err := capacity(edge)
+		if err != nil {
+			return FlowResult{}, err
+		}
+		if math.IsNaN(edgeCapacity) || math.IsInf(edgeCapacity, 0) {
+			return FlowResult{}, InvalidCapacityError{FromID: id.From, ToID: id.To, Capacity: edgeCapacity}
+		}
+		if edgeCapacity < 0 {
+			return FlowResult{}, NegativeCapacityError{FromID: id.From, ToID: id.To}
+		}
+		from, to := indices[id.From], indices[id.To]
+		fn.adjacency[from] = append(fn.adjacency[from], len(fn.arcs))
+		fn.arcs = append(fn.arcs, flowArc{to: to, capacity: edgeCapacity})
+		fn.adjacency[to] = append(fn.adjacency[to], len(fn.arcs))
+		fn.arcs = append(fn.arcs, flowArc{to: from})
+	}
+
+	result := FlowResult{
+		EdgeFlows:  make(map[EdgeID]float64),
+		SourceSide: make([]NodeID, 0),
+		SinkSide:   make([]NodeID, 0),
+		CutEdges:   make([]Edge, 0),
+	}
+	s, t := indices[source], indices[sink]
+	for fn.buildLevels(s, t) {
+		for i := range fn.next {
+			fn.next[i] = 0
+		}
+		for pushed := fn.augment(s, t, math.Inf(1)); pushed > flowEpsilon; pushed = fn.augment(s, t, math.Inf(1)) {
+			result.Value += pushed
+		}
+	}
+
+	// the last level search stopped at the cut, so the nodes it reached form the source side
+	for i, node := range allNodes {
+		if fn.levels[i] >= 0 {
+			result.SourceSide = append(result.SourceSide, node.GetID())
+		} else {
+			result.SinkSide = append(result.SinkSide, node.GetID())
+		}
+	}
+	for i, edge := range edges {
+		id := edge.GetID()
+		result.EdgeFlows[id] = fn.arcs[2*i].flow
+		if fn.levels[indices[id.From]] >= 0 && fn.levels[indices[id.To]] < 0 {
+			result.CutEdges = append(result.CutEdges, edge)
+		}
+	}
+	sort.Slice(result.CutEdges, func(i, j int) bool {
+		return lessEdgeID(result.CutEdges[i].GetID(), result.CutEdges[j].GetID())
+	})
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func buildFlowNetwork(t *testing.T) Graph {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 0; i < 6; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(0, 1, 16.0)
+	gb.AddEdge(0, 2, 13.0)
+	gb.AddEdge(1, 2, 10.0)
+	gb.AddEdge(2, 1, 4.0)
+	gb.AddEdge(1, 3, 12.0)
+	gb.AddEdge(3, 2, 9.0)
+	gb.AddEdge(2, 4, 14.0)
+	gb.AddEdge(4, 3, 7.0)
+	gb.AddEdge(3, 5, 20.0)
+	gb.AddEdge(4, 5, 4.0)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func Test_MaxFlow(t *testing.T) {
+	graph := buildFlowNetwork(t)
+	result, err := MaxFlow(graph, 0, 5, valueWeight)
+	assert.NoError(t, err)
+	assert.InDelta(t, 23.0, result.Value, 1e-9)
+	assert.Equal(t, []NodeID{0, 1, 2, 4}, result.SourceSide)
+	assert.Equal(t, []NodeID{3, 5}, result.SinkSide)
+	cut_edges := make([]EdgeID, 0)
+	for _, edge := range result.CutEdges {
+		cut_edges = append(cut_edges, edge.GetID())
+	}
+	assert.Equal(t, []EdgeID{{From: 1, To: 3}, {From: 4, To: 3}, {From: 4, To: 5}}, cut_edges)
+
+	// every edge stays within its capacity and every node other than the source and sink keeps its flow
+	edges, err := graph.GetEdges()
+	assert.NoError(t, err)
+	assert.Len(t, result.EdgeFlows, len(edges))
+	balance := make(map[NodeID]float64)
+	for _, edge := range edges {
+		capacity, err := valueWeight(edge)
+		assert.NoError(t, err)
+		flow := result.EdgeFlows[edge.GetID()]
+		assert.GreaterOrEqual(t, flow, 0.0)
+		assert.LessOrEqual(t, flow, capacity)
+		balance[edge.GetID().From] -= flow
+		balance[edge.GetID().To] += flow
+	}
+	for id := NodeID(1); id < 5; id++ {
+		assert.InDelta(t, 0.0, balance[id], 1e-9)
+	}
+	assert.InDelta(t, 23.0, balance[5], 1e-9)
+}
+
+func Test_MaxFlow_Disconnected(t *testing.T) {
+	graph := buildFlowNetwork(t)
+	result, err := MaxFlow(graph, 5, 0, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, result.Value)
+	assert.Equal(t, []NodeID{5}, result.SourceSide)
+	assert.Empty(t, result.CutEdges)
+}
+
+func Test_MaxFlow_Errors(t *testing.T) {
+	graph := buildFlowNetwork(t)
+	_, err := MaxFlow(graph, 0, 0, valueWeight)
+	assert.ErrorIs(t, err, SourceIsSinkError{NodeID: 0})
+	_, err = MaxFlow(graph, 0, 6, valueWeight)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 6})
+	_, err = MaxFlow(graph, 0, 5, func(e Edge) (float64, error) {
+		return -1, nil
+	})
+	assert.ErrorIs(t, err, NegativeCapacityError{FromID: 0, ToID: 1})
+	_, err = MaxFlow(graph, 0, 5, func(e Edge) (float64, error) {
+		return math.Inf(1), nil
+	})
+	assert.ErrorIs(t, err, InvalidCapacityError{FromID: 0, ToID: 1, Capacity: math.Inf(1)})
+	_, err = MaxFlow(graph, 0, 5, func(e Edge) (float64, error) {
+		return math.NaN(), nil
+	})
+	assert.EqualError(t, err, "edge from 0 to 1 has an invalid capacity NaN")
+
+	undirected := buildWeightedGraph(t, BuilderOptions{})
+	_, err = MaxFlow(undirected, 1, 4, valueWeight)
+	assert.ErrorIs(t, err, CannotUseForUndirectedGraphError{MethodName: "MaxFlow"})
+}
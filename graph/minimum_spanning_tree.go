@@ -0,0 +1,169 @@
+package graph
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// SpanningForest is a set of edges connecting every node of a graph to the other nodes of its connected component
+// without forming a cycle. A connected graph has a spanning tree, which is a forest with a single tree.
+type SpanningForest struct {
+	// Edges are sorted by id.
+	Edges []Edge
+	// Weight is the sum of the weights of the edges.
+	Weight float64
+}
+
+// weightedEdge is an edge along with its weight and the node it leads to.
+type weightedEdge struct {
+	Edge   Edge
+	To     NodeID
+	Weight float64
+}
+
+// lessWeightedEdge orders edges by weight and breaks ties by id,
+// which makes the minimum spanning forest unique.
+func lessWeightedEdge(a, b weightedEdge) bool {
+	if a.Weight != b.Weight {
+		return a.Weight < b.Weight
+	}
+	return lessEdgeID(a.Edge.GetID(), b.Edge.GetID())
+}
+
+func newSpanningForest(edges []weightedEdge) SpanningForest {
+	forest := SpanningForest{Edges: make([]Edge, 0, len(edges))}
+	for _, edge := range edges {
+		forest.Edges = append(forest.Edges, edge.Edge)
+		forest.Weight += edge.Weight
+	}
+	sort.Slice(forest.Edges, func(i, j int) bool {
+		return lessEdgeID(forest.Edges[i].GetID(), forest.Edges[j].GetID())
+	})
+	return forest
+}
+
+// MinimumSpanningTree finds the spanning forest of an undirected graph with the smallest total weight using Kruskal.
+// If the graph is connected, the forest is a single tree. Negative weights are allowed.
+func MinimumSpanningTree(g Graph, weight WeightFunc) (SpanningForest, error) {
+	if g.IsDirected() {
+		return SpanningForest{}, CannotUseForDirectedGraphError{MethodName: "MinimumSpanningTree"}
+	}
+	return Kruskal(g, weight)
+}
+
+// Kruskal finds the minimum spanning forest of an undirected graph by adding the lightest edges first
+// unless they would close a cycle. Equal weights are broken by edge id,
+// so it returns the same forest as Prim.
+func Kruskal(g Graph, weight WeightFunc) (SpanningForest, error) {
+	if g.IsDirected() {
+		return SpanningForest{}, CannotUseForDirectedGraphError{MethodName: "Kruskal"}
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return SpanningForest{}, err
+	}
+	candidates := make([]weightedEdge, 0, len(edges))
+	for _, edge := range edges {
+		edgeWeight, err := weight(edge)
+		if err != nil {
+			return SpanningForest{}, err
+		}
+		candidates = append(candidates, weightedEdge{Edge: edge, To: edge.GetID().To, Weight: edgeWeight})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return lessWeightedEdge(candidates[i], candidates[j])
+	})
+
+	ds := NewDisjointSet()
+	chosen := make([]weightedEdge, 0)
+	for _, candidate := range candidates {
+		if ds.Union(candidate.Edge.GetID().From, candidate.To) {
+			chosen = append(chosen, candidate)
+		}
+	}
+	return newSpanningForest(chosen), nil
+}
+
+// Prim finds the minimum spanning forest of an undirected graph by growing a tree from the smallest id
+// of every connected component, always adding the lightest edge leaving the tree.
+// Equal weights are broken by edge id, so it returns the same forest as Kruskal.
+func Prim(g Graph, weight WeightFunc) (SpanningForest, error) {
+	if g.IsDirected() {
+		return SpanningForest{}, CannotUseForDirectedGraphError{MethodName: "Prim"}
+	}
+	allNodes, err := g.GetNodes()
+	if err != nil {
+		return SpanningForest{}, err
+	}
+	inTree := make(map[NodeID]bool)
+	chosen := make([]weightedEdge, 0)
+	queue := &edgeQueue{}
+	addNode := func(node Node) error {
+		inTree[node.GetID()] = true
+		adjacent, err := getAdjacentEdges(node, false, false)
+		if err != nil {
+			return err
+		}
+		for _, next := range adjacent {
+			if inTree[next.To] {
+				continue
+			}
+			edgeWeight, err := weight(next.Edge)
+			if err != nil {
+				return err
+			}
+			heap.Push(queue, weightedEdge{Edge: next.Edge, To: next.To, Weight: edgeWeight})
+		}
+		return nil
+	}
+
+	for _, root := range allNodes {
+		if inTree[root.GetID()] {
+			continue
+		}
+		if err := addNode(root); err != nil {
+			return SpanningForest{}, err
+		}
+		for queue.Len() > 0 {
+			lightest := heap.Pop(queue).(weightedEdge)
+			if inTree[lightest.To] {
+				continue
+			}
+			chosen = append(chosen, lightest)
+			next, err := g.GetNode(lightest.To)
+			if err != nil {
+				return SpanningForest{}, err
+			}
+			if err := addNode(next); err != nil {
+				return SpanningForest{}, err
+			}
+		}
+	}
+	return newSpanningForest(chosen), nil
+}
+
+// edgeQueue is a min-heap of edges ordered by lessWeightedEdge.
+type edgeQueue []weightedEdge
+
+func (q edgeQueue) Len() int {
+	return len(q)
+}
+
+func (q edgeQueue) Less(i, j int) bool {
+	return lessWeightedEdge(q[i], q[j])
+}
+
+func (q edgeQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *edgeQueue) Push(x interface{}) {
+	*q = append(*q, x.(weightedEdge))
+}
+
+func (q *edgeQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
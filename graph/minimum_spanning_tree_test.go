@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func forestEdgeIDs(forest SpanningForest) []EdgeID {
+	ids := make([]EdgeID, 0)
+	for _, edge := range forest.Edges {
+		ids = append(ids, edge.GetID())
+	}
+	return ids
+}
+
+// buildSpanningGraph builds a graph with the components {1, 2, 3, 4} and {5, 6} and the lone node 7.
+func buildSpanningGraph(t *testing.T) Graph {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	for i := 1; i < 8; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2, 3.0)
+	gb.AddEdge(1, 2, 1.0)
+	gb.AddEdge(2, 3, 2.0)
+	gb.AddEdge(1, 3, 2.0)
+	gb.AddEdge(3, 4, 5.0)
+	gb.AddEdge(2, 4, 4.0)
+	gb.AddEdge(5, 6, -1.0)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func Test_MinimumSpanningTree(t *testing.T) {
+	graph := buildSpanningGraph(t)
+	expected_edges := []EdgeID{{From: 1, To: 2, Index: 1}, {From: 1, To: 3}, {From: 2, To: 4}, {From: 5, To: 6}}
+	for _, mst := range []func(Graph, WeightFunc) (SpanningForest, error){MinimumSpanningTree, Kruskal, Prim} {
+		actual_forest, err := mst(graph, valueWeight)
+		assert.NoError(t, err)
+		assert.Equal(t, expected_edges, forestEdgeIDs(actual_forest))
+		assert.Equal(t, 6.0, actual_forest.Weight)
+	}
+}
+
+func Test_MinimumSpanningTree_Grid(t *testing.T) {
+	// 5x5 grid where node id = 5*x + y and the weight of an edge depends on where it starts
+	gb := NewGraphBuilder()
+	for i := 0; i < 25; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	for x := 0; x < 5; x++ {
+		for y := 0; y < 5; y++ {
+			if x < 4 {
+				gb.AddEdge(NodeID(5*x+y), NodeID(5*(x+1)+y), float64((x*7+y*3)%5))
+			}
+			if y < 4 {
+				gb.AddEdge(NodeID(5*x+y), NodeID(5*x+y+1), float64((x*2+y*5)%4))
+			}
+		}
+	}
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	kruskal, err := Kruskal(graph, valueWeight)
+	assert.NoError(t, err)
+	prim, err := Prim(graph, valueWeight)
+	assert.NoError(t, err)
+	assert.Len(t, kruskal.Edges, 24)
+	assert.Equal(t, forestEdgeIDs(kruskal), forestEdgeIDs(prim))
+	assert.Equal(t, kruskal.Weight, prim.Weight)
+}
+
+func Test_MinimumSpanningTree_Directed(t *testing.T) {
+	graph := buildWeightedGraph(t, BuilderOptions{IsDirected: true})
+	_, err := MinimumSpanningTree(graph, valueWeight)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "MinimumSpanningTree"})
+	_, err = Kruskal(graph, valueWeight)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "Kruskal"})
+	_, err = Prim(graph, valueWeight)
+	assert.ErrorIs(t, err, CannotUseForDirectedGraphError{MethodName: "Prim"})
+}
@@ -0,0 +1,496 @@
+package graph
+
+import "sort"
+
+// MutableGraph is a mutable graph whose node and edge values are untyped.
+type MutableGraph = TypedMutableGraph[interface{}, interface{}]
+
+// TypedMutableGraph is a graph that can still be edited after it has been built.
+// Every edit is validated with the BuilderOptions the graph was created with
+// and returns the same errors a GraphBuilder would.
+// The neighbor ordering guarantees of Node and the edge ordering of GetEdges
+// are kept after every edit.
+type TypedMutableGraph[N, E any] interface {
+	TypedGraph[N, E]
+
+	// AddNode adds a node to the graph.
+	// If the node already exists and duplicate nodes are allowed, only its value is replaced.
+	AddNode(id NodeID, value ...N) error
+
+	// RemoveNode removes a node and every edge it is an endpoint of.
+	RemoveNode(id NodeID) error
+
+	// AddEdge adds an edge connecting two existing nodes.
+	// If the edge already exists and parallel edges are allowed, a new edge is added after the existing ones.
+	// Otherwise if duplicate edges are allowed, only the value of the existing edge is replaced.
+	AddEdge(from NodeID, to NodeID, value ...E) error
+
+	// RemoveEdge removes the edge from-to along with all of its parallel edges.
+	// In an undirected graph, RemoveEdge(a, b) is equivalent to RemoveEdge(b, a).
+	RemoveEdge(from NodeID, to NodeID) error
+
+	// RemoveEdgeByID removes a single edge. The ids of the remaining edges do not change.
+	// In an undirected graph, From and To can be given in either order.
+	RemoveEdgeByID(id EdgeID) error
+
+	// SetNodeValue replaces the value stored in an existing node.
+	SetNodeValue(id NodeID, value N) error
+
+	// SetEdgeValue replaces the value stored in an existing edge.
+	// If there are parallel edges from-to, it replaces the value of the one GetEdge returns.
+	SetEdgeValue(from NodeID, to NodeID, value E) error
+}
+
+// Edit copies g into a new mutable graph, leaving g untouched.
+// The optional builder options validate the copy and every later edit.
+// Without them, parallel edges and self-loops are allowed if g already has some.
+// IsDirected is always taken from g.
+func Edit[N, E any](g TypedGraph[N, E], bo ...BuilderOptions) (TypedMutableGraph[N, E], error) {
+	builderOptions := BuilderOptions{}
+	if len(bo) == 1 {
+		builderOptions = bo[0]
+	} else {
+		edges, err := g.GetEdges()
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range edges {
+			id := edge.GetID()
+			if id.Index > 0 {
+				builderOptions.AllowParallelEdges = true
+			}
+			if id.From == id.To {
+				builderOptions.AllowRedundantEdges = true
+			}
+		}
+	}
+	builderOptions.IsDirected = g.IsDirected()
+	builder := NewTypedGraphBuilder[N, E](builderOptions)
+
+	if err := copyGraph(g, builder); err != nil {
+		return nil, err
+	}
+	return builder.BuildMutable()
+}
+
+// copyGraph adds every node and edge of g to builder along with their values.
+func copyGraph[N, E any](g TypedGraph[N, E], builder TypedGraphBuilder[N, E]) error {
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if value, err := node.GetValue(); err == nil {
+			builder.AddNode(node.GetID(), value)
+		} else {
+			builder.AddNode(node.GetID())
+		}
+	}
+
+	edges, err := g.GetEdges()
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		id := edge.GetID()
+		if value, err := edge.GetValue(); err == nil {
+			builder.AddEdge(id.From, id.To, value)
+		} else {
+			builder.AddEdge(id.From, id.To)
+		}
+	}
+	return nil
+}
+
+// insertNodeID returns a new slice with id inserted into the sorted nodeIDs.
+func insertNodeID(nodeIDs []NodeID, id NodeID) []NodeID {
+	index := sort.Search(len(nodeIDs), func(i int) bool {
+		return nodeIDs[i] >= id
+	})
+	inserted := make([]NodeID, 0, len(nodeIDs)+1)
+	inserted = append(inserted, nodeIDs[:index]...)
+	inserted = append(inserted, id)
+	return append(inserted, nodeIDs[index:]...)
+}
+
+// removeNodeID returns a new slice without the first occurrence of id.
+func removeNodeID(nodeIDs []NodeID, id NodeID) []NodeID {
+	removed := make([]NodeID, 0, len(nodeIDs))
+	found := false
+	for _, nodeID := range nodeIDs {
+		if nodeID == id && !found {
+			found = true
+			continue
+		}
+		removed = append(removed, nodeID)
+	}
+	return removed
+}
+
+type mutableDirectedGraph[N, E any] struct {
+	*directedGraph[N, E]
+	builderOptions BuilderOptions
+}
+
+func (mg *mutableDirectedGraph[N, E]) AddNode(id NodeID, value ...N) error {
+	node, exists := mg.Nodes[id]
+	if exists && !mg.builderOptions.AllowDuplicateNodes {
+		return DuplicateNodeError{NodeID: id}
+	}
+	if len(value) > 1 {
+		return MultipleValuesForNodeError{NodeID: id}
+	}
+	if exists {
+		node.Value = wrapValue(value)
+		return nil
+	}
+	mg.Nodes[id] = &directedNode[N, E]{
+		ID:          id,
+		Incoming:    make([]NodeID, 0),
+		Outgoing:    make([]NodeID, 0),
+		RawGraphRef: mg.directedGraph,
+		Value:       wrapValue(value),
+	}
+	return nil
+}
+
+func (mg *mutableDirectedGraph[N, E]) RemoveNode(id NodeID) error {
+	node, exists := mg.Nodes[id]
+	if !exists {
+		return NodeNotFoundError{NodeID: id}
+	}
+	for _, toID := range node.Outgoing {
+		if err := mg.RemoveEdge(id, toID); err != nil {
+			return err
+		}
+	}
+	for _, fromID := range node.Incoming {
+		if fromID == id {
+			// the self loop was already removed with the outgoing edges
+			continue
+		}
+		if err := mg.RemoveEdge(fromID, id); err != nil {
+			return err
+		}
+	}
+	delete(mg.Nodes, id)
+	return nil
+}
+
+func (mg *mutableDirectedGraph[N, E]) AddEdge(from NodeID, to NodeID, value ...E) error {
+	fromNode, existsFrom := mg.Nodes[from]
+	if !existsFrom {
+		return NodeNotFoundError{NodeID: from}
+	}
+	toNode, existsTo := mg.Nodes[to]
+	if !existsTo {
+		return NodeNotFoundError{NodeID: to}
+	}
+	if from == to && !mg.builderOptions.AllowRedundantEdges {
+		return RedundantEdgeError{NodeID: from}
+	}
+	edges, err := mg.GetEdgesBetween(from, to)
+	allowParallel := mg.builderOptions.AllowParallelEdges
+	if err == nil && !allowParallel && !mg.builderOptions.AllowDuplicateEdges {
+		return DuplicateEdgeError{FromID: from, ToID: to}
+	}
+	if len(value) > 1 {
+		return MultipleValuesForEdgeError{FromID: from, ToID: to}
+	}
+	if err == nil && !allowParallel {
+		edges[0].(*directedEdge[N, E]).Value = wrapValue(value)
+		return nil
+	}
+
+	edge := &directedEdge[N, E]{
+		From:        from,
+		To:          to,
+		RawGraphRef: mg.directedGraph,
+		Value:       wrapValue(value),
+	}
+	if err == nil {
+		// new parallel edges go after the existing ones
+		edge.Index = edges[len(edges)-1].GetID().Index + 1
+		mg.addParallelEdge(edge)
+		return nil
+	}
+	if _, fromExists := mg.FromToEdges[from]; !fromExists {
+		mg.FromToEdges[from] = make(map[NodeID]*directedEdge[N, E])
+	}
+	mg.FromToEdges[from][to] = edge
+	fromNode.Outgoing = insertNodeID(fromNode.Outgoing, to)
+	toNode.Incoming = insertNodeID(toNode.Incoming, from)
+	return nil
+}
+
+func (mg *mutableDirectedGraph[N, E]) RemoveEdge(from NodeID, to NodeID) error {
+	if _, err := mg.GetEdge(from, to); err != nil {
+		return err
+	}
+	delete(mg.FromToEdges[from], to)
+	if len(mg.FromToEdges[from]) == 0 {
+		delete(mg.FromToEdges, from)
+	}
+	mg.removeParallelEdges(from, to)
+	mg.Nodes[from].Outgoing = removeNodeID(mg.Nodes[from].Outgoing, to)
+	mg.Nodes[to].Incoming = removeNodeID(mg.Nodes[to].Incoming, from)
+	return nil
+}
+
+func (mg *mutableDirectedGraph[N, E]) removeParallelEdges(from NodeID, to NodeID) {
+	if _, fromExists := mg.Parallel[from]; !fromExists {
+		return
+	}
+	delete(mg.Parallel[from], to)
+	if len(mg.Parallel[from]) == 0 {
+		delete(mg.Parallel, from)
+	}
+}
+
+func (mg *mutableDirectedGraph[N, E]) RemoveEdgeByID(id EdgeID) error {
+	edges, err := mg.GetEdgesBetween(id.From, id.To)
+	if err != nil {
+		return err
+	}
+	remaining := make([]*directedEdge[N, E], 0)
+	for _, edge := range edges {
+		if edge.GetID() != id {
+			remaining = append(remaining, edge.(*directedEdge[N, E]))
+		}
+	}
+	if len(remaining) == len(edges) {
+		return EdgeNotFoundError{FromID: id.From, ToID: id.To}
+	}
+	if len(remaining) == 0 {
+		return mg.RemoveEdge(id.From, id.To)
+	}
+	// the edge with the lowest remaining index becomes the first edge from-to
+	mg.FromToEdges[id.From][id.To] = remaining[0]
+	mg.removeParallelEdges(id.From, id.To)
+	for _, edge := range remaining[1:] {
+		mg.addParallelEdge(edge)
+	}
+	return nil
+}
+
+func (mg *mutableDirectedGraph[N, E]) SetNodeValue(id NodeID, value N) error {
+	node, exists := mg.Nodes[id]
+	if !exists {
+		return NodeNotFoundError{NodeID: id}
+	}
+	node.Value = wrapValue([]N{value})
+	return nil
+}
+
+func (mg *mutableDirectedGraph[N, E]) SetEdgeValue(from NodeID, to NodeID, value E) error {
+	edge, err := mg.GetEdge(from, to)
+	if err != nil {
+		return err
+	}
+	edge.(*directedEdge[N, E]).Value = wrapValue([]E{value})
+	return nil
+}
+
+type mutableUndirectedGraph[N, E any] struct {
+	*undirectedGraph[N, E]
+	builderOptions BuilderOptions
+}
+
+func (mg *mutableUndirectedGraph[N, E]) AddNode(id NodeID, value ...N) error {
+	node, exists := mg.Nodes[id]
+	if exists && !mg.builderOptions.AllowDuplicateNodes {
+		return DuplicateNodeError{NodeID: id}
+	}
+	if len(value) > 1 {
+		return MultipleValuesForNodeError{NodeID: id}
+	}
+	if exists {
+		node.Value = wrapValue(value)
+		return nil
+	}
+	mg.Nodes[id] = &undirectedNode[N, E]{
+		ID:          id,
+		Neighbors:   make([]NodeID, 0),
+		RawGraphRef: mg.undirectedGraph,
+		Value:       wrapValue(value),
+	}
+	return nil
+}
+
+func (mg *mutableUndirectedGraph[N, E]) RemoveNode(id NodeID) error {
+	node, exists := mg.Nodes[id]
+	if !exists {
+		return NodeNotFoundError{NodeID: id}
+	}
+	for _, neighborID := range node.Neighbors {
+		if err := mg.RemoveEdge(id, neighborID); err != nil {
+			return err
+		}
+	}
+	delete(mg.NodesEdges, id)
+	delete(mg.Parallel, id)
+	delete(mg.Nodes, id)
+	return nil
+}
+
+// insertEdge adds edge to the sorted list of edges.
+func (mg *mutableUndirectedGraph[N, E]) insertEdge(edge *undirectedEdge[N, E]) {
+	index := sort.Search(len(mg.Edges), func(i int) bool {
+		return !lessUndirectedEdge(mg.Edges[i], edge)
+	})
+	edges := make([]*undirectedEdge[N, E], 0, len(mg.Edges)+1)
+	edges = append(edges, mg.Edges[:index]...)
+	edges = append(edges, edge)
+	mg.Edges = append(edges, mg.Edges[index:]...)
+}
+
+// removeEdges removes every edge matching the filter from the sorted list of edges.
+func (mg *mutableUndirectedGraph[N, E]) removeEdges(filter func(*undirectedEdge[N, E]) bool) {
+	edges := make([]*undirectedEdge[N, E], 0, len(mg.Edges))
+	for _, edge := range mg.Edges {
+		if !filter(edge) {
+			edges = append(edges, edge)
+		}
+	}
+	mg.Edges = edges
+}
+
+func (mg *mutableUndirectedGraph[N, E]) AddEdge(first NodeID, second NodeID, value ...E) error {
+	if first > second {
+		first, second = second, first
+	}
+	firstNode, existsFirst := mg.Nodes[first]
+	if !existsFirst {
+		return NodeNotFoundError{NodeID: first}
+	}
+	secondNode, existsSecond := mg.Nodes[second]
+	if !existsSecond {
+		return NodeNotFoundError{NodeID: second}
+	}
+	if first == second && !mg.builderOptions.AllowRedundantEdges {
+		return RedundantEdgeError{NodeID: first}
+	}
+	edges, err := mg.GetEdgesBetween(first, second)
+	allowParallel := mg.builderOptions.AllowParallelEdges
+	if err == nil && !allowParallel && !mg.builderOptions.AllowDuplicateEdges {
+		return DuplicateEdgeError{FromID: first, ToID: second}
+	}
+	if len(value) > 1 {
+		return MultipleValuesForEdgeError{FromID: first, ToID: second}
+	}
+	if err == nil && !allowParallel {
+		edges[0].(*undirectedEdge[N, E]).Value = wrapValue(value)
+		return nil
+	}
+
+	edge := &undirectedEdge[N, E]{
+		Nodes:       [2]NodeID{first, second},
+		RawGraphRef: mg.undirectedGraph,
+		Value:       wrapValue(value),
+	}
+	if err == nil {
+		// new parallel edges go after the existing ones
+		edge.Index = edges[len(edges)-1].GetID().Index + 1
+		mg.addParallelEdge(edge)
+		mg.insertEdge(edge)
+		return nil
+	}
+	if _, firstExists := mg.NodesEdges[first]; !firstExists {
+		mg.NodesEdges[first] = make(map[NodeID]*undirectedEdge[N, E])
+	}
+	if _, secondExists := mg.NodesEdges[second]; !secondExists {
+		mg.NodesEdges[second] = make(map[NodeID]*undirectedEdge[N, E])
+	}
+	mg.NodesEdges[first][second] = edge
+	mg.NodesEdges[second][first] = edge
+	mg.insertEdge(edge)
+
+	// if first and second are equal to each other (i.e. self loop) then only add once
+	firstNode.Neighbors = insertNodeID(firstNode.Neighbors, second)
+	if first != second {
+		secondNode.Neighbors = insertNodeID(secondNode.Neighbors, first)
+	}
+	return nil
+}
+
+func (mg *mutableUndirectedGraph[N, E]) RemoveEdge(first NodeID, second NodeID) error {
+	if first > second {
+		first, second = second, first
+	}
+	if _, err := mg.GetEdge(first, second); err != nil {
+		return err
+	}
+	delete(mg.NodesEdges[first], second)
+	delete(mg.NodesEdges[second], first)
+	mg.removeParallelEdges(first, second)
+	mg.removeEdges(func(edge *undirectedEdge[N, E]) bool {
+		return edge.Nodes[0] == first && edge.Nodes[1] == second
+	})
+
+	mg.Nodes[first].Neighbors = removeNodeID(mg.Nodes[first].Neighbors, second)
+	if first != second {
+		mg.Nodes[second].Neighbors = removeNodeID(mg.Nodes[second].Neighbors, first)
+	}
+	return nil
+}
+
+func (mg *mutableUndirectedGraph[N, E]) removeParallelEdges(first NodeID, second NodeID) {
+	if _, firstExists := mg.Parallel[first]; firstExists {
+		delete(mg.Parallel[first], second)
+	}
+	if _, secondExists := mg.Parallel[second]; secondExists {
+		delete(mg.Parallel[second], first)
+	}
+}
+
+func (mg *mutableUndirectedGraph[N, E]) RemoveEdgeByID(id EdgeID) error {
+	// undirected edge ids have From <= To, but like RemoveEdge either order is accepted
+	if id.From > id.To {
+		id.From, id.To = id.To, id.From
+	}
+	edges, err := mg.GetEdgesBetween(id.From, id.To)
+	if err != nil {
+		return err
+	}
+	remaining := make([]*undirectedEdge[N, E], 0)
+	for _, edge := range edges {
+		if edge.GetID() != id {
+			remaining = append(remaining, edge.(*undirectedEdge[N, E]))
+		}
+	}
+	if len(remaining) == len(edges) {
+		return EdgeNotFoundError{FromID: id.From, ToID: id.To}
+	}
+	if len(remaining) == 0 {
+		return mg.RemoveEdge(id.From, id.To)
+	}
+	// the edge with the lowest remaining index becomes the first edge first-second
+	mg.NodesEdges[id.From][id.To] = remaining[0]
+	mg.NodesEdges[id.To][id.From] = remaining[0]
+	mg.removeParallelEdges(id.From, id.To)
+	for _, edge := range remaining[1:] {
+		mg.addParallelEdge(edge)
+	}
+	mg.removeEdges(func(edge *undirectedEdge[N, E]) bool {
+		return edge.GetID() == id
+	})
+	return nil
+}
+
+func (mg *mutableUndirectedGraph[N, E]) SetNodeValue(id NodeID, value N) error {
+	node, exists := mg.Nodes[id]
+	if !exists {
+		return NodeNotFoundError{NodeID: id}
+	}
+	node.Value = wrapValue([]N{value})
+	return nil
+}
+
+func (mg *mutableUndirectedGraph[N, E]) SetEdgeValue(first NodeID, second NodeID, value E) error {
+	edge, err := mg.GetEdge(first, second)
+	if err != nil {
+		return err
+	}
+	edge.(*undirectedEdge[N, E]).Value = wrapValue([]E{value})
+	return nil
+}
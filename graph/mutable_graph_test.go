@@ -0,0 +1,338 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_UndirectedMutableGraph_AddNodeAndEdge(t *testing.T) {
+	gb := NewGraphBuilder()
+	gb.AddNode(1)
+	gb.AddNode(3)
+	gb.AddEdge(1, 3)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.AddNode(2, "val2"))
+	assert.NoError(t, graph.AddEdge(2, 1, "val1-2"))
+	assert.NoError(t, graph.AddEdge(3, 2))
+
+	actual_edges, err := graph.GetEdges()
+	assert.NoError(t, err)
+	expected_edges := []Edge{
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Value: wrappedValue{HasValue: true, RawValue: "val1-2"}},
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 3}},
+		rawUndirectedEdge{Nodes: [2]NodeID{2, 3}},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+
+	actual_nodes, err := graph.GetNodes()
+	assert.NoError(t, err)
+	expected_nodes := []Node{
+		rawUndirectedNode{ID: 1, Neighbors: []NodeID{2, 3}},
+		rawUndirectedNode{ID: 2, Neighbors: []NodeID{1, 3}, Value: wrappedValue{HasValue: true, RawValue: "val2"}},
+		rawUndirectedNode{ID: 3, Neighbors: []NodeID{1, 2}},
+	}
+	AssertNodesEquals(t, expected_nodes, actual_nodes)
+
+	node, err := graph.GetNode(2)
+	assert.NoError(t, err)
+	actual_incident, err := node.GetIncidentEdges()
+	assert.NoError(t, err)
+	expected_incident := []Edge{
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Value: wrappedValue{HasValue: true, RawValue: "val1-2"}},
+		rawUndirectedEdge{Nodes: [2]NodeID{2, 3}},
+	}
+	AssertEdgesEquals(t, expected_incident, actual_incident)
+}
+
+func Test_DirectedMutableGraph_AddNodeAndEdge(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(3)
+	gb.AddEdge(1, 3)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.AddNode(2))
+	assert.NoError(t, graph.AddEdge(1, 2, "val1-2"))
+	assert.NoError(t, graph.AddEdge(3, 2))
+
+	actual_edges, err := graph.GetEdges()
+	assert.NoError(t, err)
+	expected_edges := []Edge{
+		rawDirectedEdge{From: 1, To: 2, Value: wrappedValue{HasValue: true, RawValue: "val1-2"}},
+		rawDirectedEdge{From: 1, To: 3},
+		rawDirectedEdge{From: 3, To: 2},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+
+	actual_nodes, err := graph.GetNodes()
+	assert.NoError(t, err)
+	expected_nodes := []Node{
+		rawDirectedNode{ID: 1, Outgoing: []NodeID{2, 3}, Incoming: []NodeID{}},
+		rawDirectedNode{ID: 2, Outgoing: []NodeID{}, Incoming: []NodeID{1, 3}},
+		rawDirectedNode{ID: 3, Outgoing: []NodeID{2}, Incoming: []NodeID{1}},
+	}
+	AssertNodesEquals(t, expected_nodes, actual_nodes)
+}
+
+func Test_UndirectedMutableGraph_RemoveNodeAndEdge(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 5; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(1, 3)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 4)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.RemoveEdge(3, 1))
+	assert.NoError(t, graph.RemoveNode(4))
+
+	actual_graph := graph.(*mutableUndirectedGraph[interface{}, interface{}]).undirectedGraph
+	expected_graph := &rawUndirectedGraph{
+		Edges: []*rawUndirectedEdge{
+			{Nodes: [2]NodeID{1, 2}},
+			{Nodes: [2]NodeID{2, 3}},
+		},
+		Nodes: map[NodeID]*rawUndirectedNode{
+			1: {ID: 1, Neighbors: []NodeID{2}},
+			2: {ID: 2, Neighbors: []NodeID{1, 3}},
+			3: {ID: 3, Neighbors: []NodeID{2}},
+		},
+		NodesEdges: map[NodeID]map[NodeID]*rawUndirectedEdge{
+			1: {2: {Nodes: [2]NodeID{1, 2}}},
+			2: {
+				1: {Nodes: [2]NodeID{1, 2}},
+				3: {Nodes: [2]NodeID{2, 3}},
+			},
+			3: {2: {Nodes: [2]NodeID{2, 3}}},
+		},
+	}
+	AssertGraphEquals(t, *expected_graph, *actual_graph)
+
+	_, err = graph.GetEdge(3, 4)
+	assert.ErrorIs(t, err, EdgeNotFoundError{FromID: 3, ToID: 4})
+}
+
+func Test_DirectedMutableGraph_RemoveNodeAndEdge(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 5; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 1)
+	gb.AddEdge(4, 3)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.RemoveEdge(3, 1))
+	assert.NoError(t, graph.RemoveNode(4))
+
+	actual_graph := graph.(*mutableDirectedGraph[interface{}, interface{}]).directedGraph
+	expected_graph := &rawDirectedGraph{
+		Nodes: map[NodeID]*rawDirectedNode{
+			1: {ID: 1, Outgoing: []NodeID{2}, Incoming: []NodeID{}},
+			2: {ID: 2, Outgoing: []NodeID{3}, Incoming: []NodeID{1}},
+			3: {ID: 3, Outgoing: []NodeID{}, Incoming: []NodeID{2}},
+		},
+		FromToEdges: map[NodeID]map[NodeID]*rawDirectedEdge{
+			1: {2: {From: 1, To: 2}},
+			2: {3: {From: 2, To: 3}},
+		},
+	}
+	AssertGraphEquals(t, *expected_graph, *actual_graph)
+}
+
+func Test_MutableGraph_SetValues(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.SetNodeValue(1, "node-val"))
+	assert.NoError(t, graph.SetEdgeValue(1, 2, "edge-val"))
+	node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	actual_value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "node-val", actual_value)
+	edge, err := graph.GetEdge(1, 2)
+	assert.NoError(t, err)
+	actual_value, err = edge.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "edge-val", actual_value)
+
+	assert.ErrorIs(t, graph.SetNodeValue(3, "val"), NodeNotFoundError{NodeID: 3})
+	assert.ErrorIs(t, graph.SetEdgeValue(2, 1, "val"), EdgeNotFoundError{FromID: 2, ToID: 1})
+}
+
+func Test_MutableGraph_Errors(t *testing.T) {
+	gb := NewGraphBuilder()
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, graph.AddNode(1), DuplicateNodeError{NodeID: 1})
+	assert.ErrorIs(t, graph.AddNode(3, "value 1", "value 2"), MultipleValuesForNodeError{NodeID: 3})
+	assert.ErrorIs(t, graph.AddEdge(2, 1), DuplicateEdgeError{FromID: 1, ToID: 2})
+	assert.ErrorIs(t, graph.AddEdge(1, 1), RedundantEdgeError{NodeID: 1})
+	assert.ErrorIs(t, graph.AddEdge(1, 3), NodeNotFoundError{NodeID: 3})
+	assert.ErrorIs(t, graph.RemoveNode(3), NodeNotFoundError{NodeID: 3})
+	assert.ErrorIs(t, graph.RemoveEdge(1, 3), EdgeNotFoundError{FromID: 1, ToID: 3})
+}
+
+func Test_MutableGraph_AllowDuplicates(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowDuplicateNodes: true, AllowDuplicateEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.AddNode(1, "node-val"))
+	assert.NoError(t, graph.AddEdge(2, 1, "edge-val"))
+	actual_node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	expected_node := rawUndirectedNode{ID: 1, Neighbors: []NodeID{2}, Value: wrappedValue{HasValue: true, RawValue: "node-val"}}
+	AssertNodeEquals(t, expected_node, actual_node)
+	actual_edges, err := graph.GetEdges()
+	assert.NoError(t, err)
+	expected_edges := []Edge{
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Value: wrappedValue{HasValue: true, RawValue: "edge-val"}},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+}
+
+func Test_Edit(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1, "val1")
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "val1-2")
+	original, err := gb.Build()
+	assert.NoError(t, err)
+
+	graph, err := Edit(original)
+	assert.NoError(t, err)
+	assert.True(t, graph.IsDirected())
+	AssertGraphEquals(t, original, *graph.(*mutableDirectedGraph[interface{}, interface{}]).directedGraph)
+
+	// editing the copy leaves the original untouched
+	assert.NoError(t, graph.RemoveNode(2))
+	_, err = original.GetNode(2)
+	assert.NoError(t, err)
+	_, err = original.GetEdge(1, 2)
+	assert.NoError(t, err)
+}
+
+func Test_Edit_ParallelEdges(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true, AllowRedundantEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(1, 2, "b")
+	gb.AddEdge(3, 3)
+	original, err := gb.Build()
+	assert.NoError(t, err)
+
+	// without options the copy allows the kinds of edges the original has
+	graph, err := Edit(original)
+	assert.NoError(t, err)
+	expected_edges, err := original.GetEdges()
+	assert.NoError(t, err)
+	actual_edges, err := graph.GetEdges()
+	assert.NoError(t, err)
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+	assert.NoError(t, graph.AddEdge(2, 3))
+	assert.NoError(t, graph.AddEdge(2, 3))
+	assert.NoError(t, graph.AddEdge(2, 2))
+
+	// but a graph without them stays strict
+	gb = NewGraphBuilder()
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	strict, err := gb.Build()
+	assert.NoError(t, err)
+	graph, err = Edit(strict)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, graph.AddEdge(2, 2), RedundantEdgeError{NodeID: 2})
+
+	// and explicit options are used as given
+	_, err = Edit(original, BuilderOptions{})
+	assert.Error(t, err)
+}
+
+func Test_UndirectedMutableGraph_ParallelEdges(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(2, 3)
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.AddEdge(2, 1, "b"))
+	assert.NoError(t, graph.AddEdge(1, 2, "c"))
+	assert.NoError(t, graph.RemoveEdgeByID(EdgeID{From: 1, To: 2, Index: 0}))
+	actual_edges, err := graph.GetEdges()
+	assert.NoError(t, err)
+	expected_edges := []Edge{
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Index: 1, Value: wrappedValue{HasValue: true, RawValue: "b"}},
+		rawUndirectedEdge{Nodes: [2]NodeID{1, 2}, Index: 2, Value: wrappedValue{HasValue: true, RawValue: "c"}},
+		rawUndirectedEdge{Nodes: [2]NodeID{2, 3}},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+	actual_edge, err := graph.GetEdge(2, 1)
+	assert.NoError(t, err)
+	AssertEdgeEquals(t, expected_edges[0], actual_edge)
+
+	assert.ErrorIs(t, graph.RemoveEdgeByID(EdgeID{From: 1, To: 2, Index: 0}), EdgeNotFoundError{FromID: 1, ToID: 2})
+	// the nodes of an undirected edge id can be given in either order
+	assert.NoError(t, graph.RemoveEdgeByID(EdgeID{From: 2, To: 1, Index: 2}))
+	actual_edges, err = graph.GetEdgesBetween(2, 1)
+	assert.NoError(t, err)
+	AssertEdgesEquals(t, expected_edges[:1], actual_edges)
+	assert.NoError(t, graph.RemoveEdge(1, 2))
+	_, err = graph.GetEdgesBetween(1, 2)
+	assert.ErrorIs(t, err, EdgeNotFoundError{FromID: 1, ToID: 2})
+	actual_node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	AssertNodeEquals(t, rawUndirectedNode{ID: 1, Neighbors: []NodeID{}}, actual_node)
+}
+
+func Test_DirectedMutableGraph_ParallelEdges(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true, IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "a")
+	graph, err := gb.BuildMutable()
+	assert.NoError(t, err)
+
+	assert.NoError(t, graph.AddEdge(1, 2, "b"))
+	assert.NoError(t, graph.RemoveEdgeByID(EdgeID{From: 1, To: 2, Index: 1}))
+	assert.NoError(t, graph.AddEdge(1, 2, "c"))
+	actual_edges, err := graph.GetEdgesBetween(1, 2)
+	assert.NoError(t, err)
+	expected_edges := []Edge{
+		rawDirectedEdge{From: 1, To: 2, Value: wrappedValue{HasValue: true, RawValue: "a"}},
+		rawDirectedEdge{From: 1, To: 2, Index: 1, Value: wrappedValue{HasValue: true, RawValue: "c"}},
+	}
+	AssertEdgesEquals(t, expected_edges, actual_edges)
+
+	assert.NoError(t, graph.RemoveNode(2))
+	actual_edges, err = graph.GetEdges()
+	assert.NoError(t, err)
+	AssertEdgesEquals(t, []Edge{}, actual_edges)
+}
@@ -4,72 +4,80 @@ import "sort"
 
 type NodeID int
 
-// Node represents a node in a graph with access to its
+// Node represents a node in a graph whose node and edge values are untyped.
+type Node = TypedNode[interface{}, interface{}]
+
+// TypedNode represents a node in a graph with access to its
 // incident edges. If its in a directed graph, it also has access
-// to its incoming/outgoing edges. It can also store a value.
-type Node interface {
+// to its incoming/outgoing edges. It can also store a value of type N.
+type TypedNode[N, E any] interface {
 	// GetID returns the node's unique identifier.
 	GetID() NodeID
 
 	// GetIncomingEdges returns the edges that are pointing to this node in a directed graph.
-	// The edges are sorted by NodeID on the other side of the incoming edge (ascending).
+	// The edges are sorted by NodeID on the other side of the incoming edge (ascending),
+	// then parallel edges are sorted by their index (ascending).
 	// In a undirected graph, this returns a "cannot use this method" error.
-	GetIncomingEdges() ([]Edge, error)
+	GetIncomingEdges() ([]TypedEdge[N, E], error)
 
 	// GetOutgoingEdges returns the edges that are stemming from this node in a directed graph.
-	// The edges are sorted by NodeID on the other side of the outgoing edge (ascending).
+	// The edges are sorted by NodeID on the other side of the outgoing edge (ascending),
+	// then parallel edges are sorted by their index (ascending).
 	// In a undirected graph, this returns a "cannot use this method" error
-	GetOutgoingEdges() ([]Edge, error)
+	GetOutgoingEdges() ([]TypedEdge[N, E], error)
 
 	// GetIncidentEdges returns all the edges that this node is an endpoint of (directed or undirected).
 	// If the edges are from an undirected graph, the nodes in each edge will be sorted by id (ascending).
-	// Then the edges are sorted by the first entry and the second entry in this NodeID slice (ascending).
+	// Then the edges are sorted by the first entry and the second entry in this NodeID slice (ascending)
+	// and parallel edges by their index (ascending).
 	// If the edges are from a directed graph, the incoming edges are first then the outgoing edges.
-	GetIncidentEdges() ([]Edge, error)
+	GetIncidentEdges() ([]TypedEdge[N, E], error)
 
 	// GetValue return the value stored in this node.
 	// If there is no value then this returns a "no value" error.
-	GetValue() (interface{}, error)
-	removeRef() Node
+	GetValue() (N, error)
+	removeRef() TypedNode[N, E]
 }
 
-type rawDirectedNode struct {
+type rawDirectedNode = directedNode[interface{}, interface{}]
+
+type directedNode[N, E any] struct {
 	ID          NodeID
 	Incoming    []NodeID
 	Outgoing    []NodeID
-	RawGraphRef *rawDirectedGraph
-	Value       wrappedValue
+	RawGraphRef *directedGraph[N, E]
+	Value       typedValue[N]
 }
 
-func (rn rawDirectedNode) GetID() NodeID {
+func (rn directedNode[N, E]) GetID() NodeID {
 	return rn.ID
 }
 
-func (rn rawDirectedNode) GetIncomingEdges() ([]Edge, error) {
-	incoming := make([]Edge, 0)
+func (rn directedNode[N, E]) GetIncomingEdges() ([]TypedEdge[N, E], error) {
+	incoming := make([]TypedEdge[N, E], 0)
 	for _, fromID := range rn.Incoming {
-		edge, err := rn.RawGraphRef.GetEdge(fromID, rn.ID)
+		edges, err := rn.RawGraphRef.GetEdgesBetween(fromID, rn.ID)
 		if err != nil {
 			return nil, err
 		}
-		incoming = append(incoming, edge)
+		incoming = append(incoming, edges...)
 	}
 	return incoming, nil
 }
 
-func (rn rawDirectedNode) GetOutgoingEdges() ([]Edge, error) {
-	outgoing := make([]Edge, 0)
+func (rn directedNode[N, E]) GetOutgoingEdges() ([]TypedEdge[N, E], error) {
+	outgoing := make([]TypedEdge[N, E], 0)
 	for _, toID := range rn.Outgoing {
-		edge, err := rn.RawGraphRef.GetEdge(rn.ID, toID)
+		edges, err := rn.RawGraphRef.GetEdgesBetween(rn.ID, toID)
 		if err != nil {
 			return nil, err
 		}
-		outgoing = append(outgoing, edge)
+		outgoing = append(outgoing, edges...)
 	}
 	return outgoing, nil
 }
 
-func (rn rawDirectedNode) GetIncidentEdges() ([]Edge, error) {
+func (rn directedNode[N, E]) GetIncidentEdges() ([]TypedEdge[N, E], error) {
 	incoming, err := rn.GetIncomingEdges()
 	if err != nil {
 		return nil, err
@@ -82,62 +90,66 @@ func (rn rawDirectedNode) GetIncidentEdges() ([]Edge, error) {
 	return incident, nil
 }
 
-func (rn rawDirectedNode) GetValue() (interface{}, error) {
+func (rn directedNode[N, E]) GetValue() (N, error) {
 	if !rn.Value.HasValue {
-		return nil, noValueFoundInNodeError{rn.ID}
+		var zero N
+		return zero, NoValueFoundInNodeError{rn.ID}
 	}
 	return rn.Value.RawValue, nil
 }
 
-func (rn rawDirectedNode) removeRef() Node {
+func (rn directedNode[N, E]) removeRef() TypedNode[N, E] {
 	rn.RawGraphRef = nil
 	return rn
 }
 
-type rawUndirectedNode struct {
+type rawUndirectedNode = undirectedNode[interface{}, interface{}]
+
+type undirectedNode[N, E any] struct {
 	ID          NodeID
 	Neighbors   []NodeID
-	RawGraphRef *rawUndirectedGraph
-	Value       wrappedValue
+	RawGraphRef *undirectedGraph[N, E]
+	Value       typedValue[N]
 }
 
-func (rn rawUndirectedNode) GetID() NodeID {
+func (rn undirectedNode[N, E]) GetID() NodeID {
 	return rn.ID
 }
 
-func (rn rawUndirectedNode) GetIncomingEdges() ([]Edge, error) {
-	return nil, cannotUseForUndirectedGraphError{"Node.GetIncomingEdges"}
+func (rn undirectedNode[N, E]) GetIncomingEdges() ([]TypedEdge[N, E], error) {
+	return nil, CannotUseForUndirectedGraphError{"Node.GetIncomingEdges"}
 }
 
-func (rn rawUndirectedNode) GetOutgoingEdges() ([]Edge, error) {
-	return nil, cannotUseForUndirectedGraphError{"Node.GetOutgoingEdges"}
+func (rn undirectedNode[N, E]) GetOutgoingEdges() ([]TypedEdge[N, E], error) {
+	return nil, CannotUseForUndirectedGraphError{"Node.GetOutgoingEdges"}
 }
 
-func (rn rawUndirectedNode) GetIncidentEdges() ([]Edge, error) {
-	edges := make([]Edge, 0)
+func (rn undirectedNode[N, E]) GetIncidentEdges() ([]TypedEdge[N, E], error) {
+	edges := make([]TypedEdge[N, E], 0)
 	for _, nodeID := range rn.Neighbors {
-		edge, err := rn.RawGraphRef.GetEdge(rn.ID, nodeID)
+		parallelEdges, err := rn.RawGraphRef.GetEdgesBetween(rn.ID, nodeID)
 		if err != nil {
 			return nil, err
 		}
-		edges = append(edges, edge)
+		edges = append(edges, parallelEdges...)
 	}
 	return edges, nil
 }
 
-func (rn rawUndirectedNode) GetValue() (interface{}, error) {
+func (rn undirectedNode[N, E]) GetValue() (N, error) {
 	if !rn.Value.HasValue {
-		return nil, noValueFoundInNodeError{rn.ID}
+		var zero N
+		return zero, NoValueFoundInNodeError{rn.ID}
 	}
 	return rn.Value.RawValue, nil
 }
 
-func (rn rawUndirectedNode) removeRef() Node {
+func (rn undirectedNode[N, E]) removeRef() TypedNode[N, E] {
 	rn.RawGraphRef = nil
 	return rn
 }
 
-func sortNodes(nodes []Node) {
+func sortNodes[N, E any](nodes []TypedNode[N, E]) {
 	sort.Slice(nodes, func(i, j int) bool {
 		return nodes[i].GetID() < nodes[j].GetID()
 	})
@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelOptions configures how many goroutines a parallel algorithm uses.
+type ParallelOptions struct {
+	// Workers is the number of goroutines that share the work.
+	// Zero or negative uses runtime.GOMAXPROCS(0).
+	Workers int
+}
+
+func (po ParallelOptions) workers() int {
+	if po.Workers > 0 {
+		return po.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// ParallelBreadthFirstSearchOptions configures ParallelBreadthFirstSearch.
+// The fields match BreadthFirstSearchOptions, which has no hooks here
+// because the nodes of a level are explored at the same time.
+type ParallelBreadthFirstSearchOptions struct {
+	ParallelOptions
+	// Sources are the nodes the search starts from, all at depth 0.
+	// If empty, every node not reached yet starts a new search in ascending id order.
+	Sources []Node
+	// MaxDepth stops the search from following edges of nodes this many edges away from the sources,
+	// so a MaxDepth of 0 only visits the sources. Nil means there is no limit and a negative depth is an error.
+	MaxDepth     *int
+	ReverseGraph bool
+	IsUndirected bool
+}
+
+// ParallelBreadthFirstSearch computes the same result as BreadthFirstSearch,
+// splitting the nodes of every level between the workers.
+// The nodes reached by each worker are merged in level order, so the parents are the ones BreadthFirstSearch picks.
+// g must be safe for concurrent use, see Freeze.
+func ParallelBreadthFirstSearch(g Graph, options ParallelBreadthFirstSearchOptions) (BreadthFirstSearchResult, error) {
+	if !g.IsDirected() {
+		options.IsUndirected = true
+	}
+	result := BreadthFirstSearchResult{
+		Depths:  make(map[NodeID]int),
+		Parents: make(map[NodeID]Edge),
+	}
+	if options.MaxDepth != nil && *options.MaxDepth < 0 {
+		return result, NegativeMaxDepthError{MaxDepth: *options.MaxDepth}
+	}
+	if len(options.Sources) > 0 {
+		return result, parallelBreadthFirstSearchHelper(g, options.Sources, result, options)
+	}
+	allNodes, err := g.GetNodes()
+	if err != nil {
+		return result, err
+	}
+	for _, node := range allNodes {
+		if _, reached := result.Depths[node.GetID()]; reached {
+			continue
+		}
+		if err := parallelBreadthFirstSearchHelper(g, []Node{node}, result, options); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func parallelBreadthFirstSearchHelper(g Graph, sources []Node, result BreadthFirstSearchResult, options ParallelBreadthFirstSearchOptions) error {
+	level := make([]Node, 0)
+	for _, source := range sources {
+		if _, reached := result.Depths[source.GetID()]; !reached {
+			result.Depths[source.GetID()] = 0
+			level = append(level, source)
+		}
+	}
+	for depth := 0; len(level) > 0; depth++ {
+		if options.MaxDepth != nil && depth >= *options.MaxDepth {
+			return nil
+		}
+		chunks := splitNodes(level, options.workers())
+		reached := make([][]adjacentEdge, len(chunks))
+		errs := make([]error, len(chunks))
+		var wg sync.WaitGroup
+		for i, chunk := range chunks {
+			wg.Add(1)
+			go func(i int, chunk []Node) {
+				defer wg.Done()
+				for _, node := range chunk {
+					adjacent, err := getAdjacentEdges(node, !options.IsUndirected, options.ReverseGraph)
+					if err != nil {
+						errs[i] = err
+						return
+					}
+					for _, next := range adjacent {
+						// the depths are only written between levels, so they can be read here
+						if _, seen := result.Depths[next.To]; !seen {
+							reached[i] = append(reached[i], next)
+						}
+					}
+				}
+			}(i, chunk)
+		}
+		wg.Wait()
+		if err := firstError(errs); err != nil {
+			return err
+		}
+
+		nextLevel := make([]Node, 0)
+		for _, chunkReached := range reached {
+			for _, next := range chunkReached {
+				if _, seen := result.Depths[next.To]; seen {
+					continue
+				}
+				nextNode, err := g.GetNode(next.To)
+				if err != nil {
+					return err
+				}
+				result.Depths[next.To] = depth + 1
+				result.Parents[next.To] = next.Edge
+				nextLevel = append(nextLevel, nextNode)
+			}
+		}
+		level = nextLevel
+	}
+	return nil
+}
+
+// splitNodes splits nodes into at most n contiguous chunks of about the same size.
+func splitNodes(nodes []Node, n int) [][]Node {
+	size := (len(nodes) + n - 1) / n
+	chunks := make([][]Node, 0, n)
+	for start := 0; start < len(nodes); start += size {
+		end := start + size
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		chunks = append(chunks, nodes[start:end])
+	}
+	return chunks
+}
+
+// firstError returns the first error that is not nil.
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forEachNode calls run with every node, sharing them between the workers.
+// It returns the error of the earliest node, so the error does not depend on scheduling.
+func forEachNode(nodes []Node, options ParallelOptions, run func(i int, node Node) error) error {
+	errs := make([]error, len(nodes))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < options.workers(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = run(i, nodes[i])
+			}
+		}()
+	}
+	for i := range nodes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return firstError(errs)
+}
+
+// AllPairsDistances returns the number of edges on the shortest path between every pair of nodes,
+// running a breadth first search from every node in parallel.
+// The distances of a node only include the nodes it can reach, itself included at distance 0.
+// g must be safe for concurrent use, see Freeze.
+func AllPairsDistances(g Graph, options ParallelOptions) (map[NodeID]map[NodeID]int, error) {
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	depths := make([]map[NodeID]int, len(nodes))
+	err = forEachNode(nodes, options, func(i int, node Node) error {
+		result, err := BreadthFirstSearch(g, BreadthFirstSearchOptions{Sources: []Node{node}})
+		depths[i] = result.Depths
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	distances := make(map[NodeID]map[NodeID]int)
+	for i, node := range nodes {
+		distances[node.GetID()] = depths[i]
+	}
+	return distances, nil
+}
+
+// AllPairsShortestPaths runs ShortestPaths from every node of g in parallel
+// and returns the shortest path tree of every node.
+// g and weight must be safe for concurrent use, see Freeze.
+func AllPairsShortestPaths(g Graph, weight WeightFunc, options ParallelOptions) (map[NodeID]ShortestPathTree, error) {
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	trees := make([]ShortestPathTree, len(nodes))
+	err = forEachNode(nodes, options, func(i int, node Node) error {
+		tree, err := ShortestPaths(g, node.GetID(), weight)
+		trees[i] = tree
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	allTrees := make(map[NodeID]ShortestPathTree)
+	for i, node := range nodes {
+		allTrees[node.GetID()] = trees[i]
+	}
+	return allTrees, nil
+}
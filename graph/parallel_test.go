@@ -0,0 +1,121 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildGridGraph builds a width by height grid where node y*width+x is connected to its right and lower neighbors.
+func buildGridGraph(t testing.TB, bo BuilderOptions, width int, height int) Graph {
+	gb := NewGraphBuilder(bo)
+	for i := 0; i < width*height; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			id := NodeID(y*width + x)
+			if x+1 < width {
+				gb.AddEdge(id, id+1, 1.0)
+			}
+			if y+1 < height {
+				gb.AddEdge(id, id+NodeID(width), 2.0)
+			}
+		}
+	}
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func edgeIDsByNode(edges map[NodeID]Edge) map[NodeID]EdgeID {
+	ids := make(map[NodeID]EdgeID)
+	for id, edge := range edges {
+		ids[id] = edge.GetID()
+	}
+	return ids
+}
+
+func Test_ParallelBreadthFirstSearch(t *testing.T) {
+	for _, bo := range []BuilderOptions{{}, {IsDirected: true}} {
+		graph := buildGridGraph(t, bo, 12, 9)
+		source, err := graph.GetNode(40)
+		assert.NoError(t, err)
+		maxDepth, sourceOnly := 3, 0
+		for _, options := range []BreadthFirstSearchOptions{
+			{},
+			{Sources: []Node{source}},
+			{Sources: []Node{source}, MaxDepth: &maxDepth},
+			{Sources: []Node{source}, MaxDepth: &sourceOnly},
+			{Sources: []Node{source}, ReverseGraph: true},
+		} {
+			expected_result, err := BreadthFirstSearch(graph, options)
+			assert.NoError(t, err)
+			for _, workers := range []int{0, 1, 3, 16} {
+				actual_result, err := ParallelBreadthFirstSearch(graph, ParallelBreadthFirstSearchOptions{
+					ParallelOptions: ParallelOptions{Workers: workers},
+					Sources:         options.Sources,
+					MaxDepth:        options.MaxDepth,
+					ReverseGraph:    options.ReverseGraph,
+				})
+				assert.NoError(t, err)
+				assert.Equal(t, expected_result.Depths, actual_result.Depths)
+				assert.Equal(t, edgeIDsByNode(expected_result.Parents), edgeIDsByNode(actual_result.Parents))
+			}
+		}
+	}
+}
+
+func Test_ParallelBreadthFirstSearch_NegativeMaxDepth(t *testing.T) {
+	graph := buildGridGraph(t, BuilderOptions{}, 3, 3)
+	source, err := graph.GetNode(0)
+	assert.NoError(t, err)
+	maxDepth := -1
+	_, err = ParallelBreadthFirstSearch(graph, ParallelBreadthFirstSearchOptions{
+		Sources:  []Node{source},
+		MaxDepth: &maxDepth,
+	})
+	assert.ErrorIs(t, err, NegativeMaxDepthError{MaxDepth: -1})
+}
+
+func Test_AllPairsDistances(t *testing.T) {
+	graph := buildGridGraph(t, BuilderOptions{IsDirected: true}, 5, 4)
+	actual_distances, err := AllPairsDistances(graph, ParallelOptions{Workers: 4})
+	assert.NoError(t, err)
+	assert.Len(t, actual_distances, 20)
+	assert.Equal(t, 7, actual_distances[0][19])
+	assert.Equal(t, map[NodeID]int{19: 0}, actual_distances[19])
+	for source, distances := range actual_distances {
+		node, err := graph.GetNode(source)
+		assert.NoError(t, err)
+		expected_result, err := BreadthFirstSearch(graph, BreadthFirstSearchOptions{Sources: []Node{node}})
+		assert.NoError(t, err)
+		assert.Equal(t, expected_result.Depths, distances)
+	}
+}
+
+func Test_AllPairsShortestPaths(t *testing.T) {
+	graph := buildGridGraph(t, BuilderOptions{}, 5, 4)
+	actual_trees, err := AllPairsShortestPaths(graph, valueWeight, ParallelOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, actual_trees, 20)
+	for source, tree := range actual_trees {
+		expected_tree, err := ShortestPaths(graph, source, valueWeight)
+		assert.NoError(t, err)
+		assert.Equal(t, expected_tree.Distances, tree.Distances)
+	}
+	path, err := actual_trees[0].PathTo(19)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0+6.0, path.Cost)
+
+	gb := NewGraphBuilder()
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2, 1.0)
+	gb.AddEdge(2, 3, -1.0)
+	graph, err = gb.Build()
+	assert.NoError(t, err)
+	_, err = AllPairsShortestPaths(graph, valueWeight, ParallelOptions{Workers: 2})
+	assert.ErrorIs(t, err, NegativeWeightError{FromID: 2, ToID: 3})
+}
@@ -0,0 +1,219 @@
+package graph
+
+import (
+	"container/heap"
+	"math"
+)
+
+// WeightFunc returns the cost of traversing an edge, usually read from the edge's GetValue.
+// The shortest path searches only accept weights that are finite and not negative.
+type WeightFunc func(Edge) (float64, error)
+
+// Heuristic estimates the cost of the cheapest path from a node to the target node.
+// AStar only returns the cheapest path if the heuristic is consistent: it is 0 at the target
+// and never exceeds the weight of an edge plus the estimate from the other end of that edge.
+// With an inconsistent heuristic AStar still returns a valid path, but maybe not the cheapest one.
+type Heuristic func(from Node, target Node) (float64, error)
+
+// Path is a walk through a graph.
+// Edges[i] connects Nodes[i] to Nodes[i+1] and Cost is the sum of the edge weights.
+type Path struct {
+	Nodes []Node
+	Edges []Edge
+	Cost  float64
+}
+
+// ShortestPathTree holds the cheapest distance from a source node to every node it can reach.
+type ShortestPathTree struct {
+	Source NodeID
+	// Distances maps every reachable node to the cost of the cheapest path from the source.
+	Distances map[NodeID]float64
+	// Predecessors maps every reachable node except the source to the last edge
+	// of the cheapest path from the source.
+	Predecessors map[NodeID]Edge
+	graph        Graph
+}
+
+// PathTo rebuilds the cheapest path from the source node to the target node.
+// If the target cannot be reached, it returns a no path found error.
+func (spt ShortestPathTree) PathTo(target NodeID) (Path, error) {
+	cost, reachable := spt.Distances[target]
+	if !reachable {
+		return Path{}, NoPathFoundError{FromID: spt.Source, ToID: target}
+	}
+	reversedNodeIDs := []NodeID{target}
+	reversedEdges := make([]Edge, 0)
+	for current := target; current != spt.Source; {
+		edge := spt.Predecessors[current]
+		previous, err := otherEndpoint(edge, current)
+		if err != nil {
+			return Path{}, err
+		}
+		if spt.graph.IsDirected() {
+			from, err := edge.GetFrom()
+			if err != nil {
+				return Path{}, err
+			}
+			previous = from.GetID()
+		}
+		reversedEdges = append(reversedEdges, edge)
+		reversedNodeIDs = append(reversedNodeIDs, previous)
+		current = previous
+	}
+
+	path := Path{Nodes: make([]Node, 0), Edges: make([]Edge, 0), Cost: cost}
+	for i := len(reversedNodeIDs) - 1; i >= 0; i-- {
+		node, err := spt.graph.GetNode(reversedNodeIDs[i])
+		if err != nil {
+			return Path{}, err
+		}
+		path.Nodes = append(path.Nodes, node)
+	}
+	for i := len(reversedEdges) - 1; i >= 0; i-- {
+		path.Edges = append(path.Edges, reversedEdges[i])
+	}
+	return path, nil
+}
+
+// ShortestPaths runs Dijkstra's algorithm from the source node and returns
+// the cheapest distance to every node the source can reach.
+// Directed graphs follow outgoing edges, undirected graphs follow incident edges.
+func ShortestPaths(g Graph, source NodeID, weight WeightFunc) (ShortestPathTree, error) {
+	return searchShortestPaths(g, source, nil, weight, nil)
+}
+
+// ShortestPath runs Dijkstra's algorithm to find the cheapest path from one node to another.
+// If the target cannot be reached, it returns a no path found error.
+func ShortestPath(g Graph, from NodeID, to NodeID, weight WeightFunc) (Path, error) {
+	return AStar(g, from, to, weight, nil)
+}
+
+// AStar finds the cheapest path from one node to another, using the heuristic
+// to explore the nodes that look closest to the target first.
+// A nil heuristic makes it equivalent to ShortestPath.
+// If the target cannot be reached, it returns a no path found error.
+func AStar(g Graph, from NodeID, to NodeID, weight WeightFunc, heuristic Heuristic) (Path, error) {
+	target, err := g.GetNode(to)
+	if err != nil {
+		return Path{}, err
+	}
+	spt, err := searchShortestPaths(g, from, target, weight, heuristic)
+	if err != nil {
+		return Path{}, err
+	}
+	return spt.PathTo(to)
+}
+
+// searchShortestPaths is Dijkstra's algorithm with an optional target to stop at
+// and an optional heuristic that turns it into A*.
+func searchShortestPaths(g Graph, source NodeID, target Node, weight WeightFunc, heuristic Heuristic) (ShortestPathTree, error) {
+	spt := ShortestPathTree{
+		Source:       source,
+		Distances:    make(map[NodeID]float64),
+		Predecessors: make(map[NodeID]Edge),
+		graph:        g,
+	}
+	sourceNode, err := g.GetNode(source)
+	if err != nil {
+		return spt, err
+	}
+	estimate := func(node Node) (float64, error) {
+		if heuristic == nil || target == nil {
+			return 0, nil
+		}
+		return heuristic(node, target)
+	}
+
+	queue := &nodeQueue{}
+	sourceEstimate, err := estimate(sourceNode)
+	if err != nil {
+		return spt, err
+	}
+	spt.Distances[source] = 0
+	heap.Push(queue, queuedNode{Node: sourceNode, Distance: 0, Priority: sourceEstimate})
+	settled := make(map[NodeID]bool)
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(queuedNode)
+		currentID := current.Node.GetID()
+		if settled[currentID] || current.Distance > spt.Distances[currentID] {
+			continue
+		}
+		settled[currentID] = true
+		if target != nil && currentID == target.GetID() {
+			break
+		}
+
+		adjacent, err := getAdjacentEdges(current.Node, g.IsDirected(), false)
+		if err != nil {
+			return spt, err
+		}
+		for _, next := range adjacent {
+			// a settled node is never expanded again, so its distance must not change
+			if settled[next.To] {
+				continue
+			}
+			edgeWeight, err := weight(next.Edge)
+			if err != nil {
+				return spt, err
+			}
+			if math.IsNaN(edgeWeight) || math.IsInf(edgeWeight, 0) {
+				return spt, InvalidWeightError{FromID: currentID, ToID: next.To, Weight: edgeWeight}
+			}
+			if edgeWeight < 0 {
+				return spt, NegativeWeightError{FromID: currentID, ToID: next.To}
+			}
+			distance := current.Distance + edgeWeight
+			if known, seen := spt.Distances[next.To]; seen && known <= distance {
+				continue
+			}
+			nextNode, err := g.GetNode(next.To)
+			if err != nil {
+				return spt, err
+			}
+			nextEstimate, err := estimate(nextNode)
+			if err != nil {
+				return spt, err
+			}
+			spt.Distances[next.To] = distance
+			spt.Predecessors[next.To] = next.Edge
+			heap.Push(queue, queuedNode{Node: nextNode, Distance: distance, Priority: distance + nextEstimate})
+		}
+	}
+	return spt, nil
+}
+
+type queuedNode struct {
+	Node     Node
+	Distance float64
+	Priority float64
+}
+
+// nodeQueue is a min-heap of nodes ordered by priority and then by id
+// so that ties are always broken the same way.
+type nodeQueue []queuedNode
+
+func (q nodeQueue) Len() int {
+	return len(q)
+}
+
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].Priority != q[j].Priority {
+		return q[i].Priority < q[j].Priority
+	}
+	return q[i].Node.GetID() < q[j].Node.GetID()
+}
+
+func (q nodeQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *nodeQueue) Push(x interface{}) {
+	*q = append(*q, x.(queuedNode))
+}
+
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	last := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return last
+}
@@ -0,0 +1,210 @@
+package graph
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// valueWeight weighs an edge by its float64 value.
+func valueWeight(e Edge) (float64, error) {
+	value, err := e.GetValue()
+	if err != nil {
+		return 0, err
+	}
+	return value.(float64), nil
+}
+
+func nodeIDs(nodes []Node) []NodeID {
+	ids := make([]NodeID, 0)
+	for _, node := range nodes {
+		ids = append(ids, node.GetID())
+	}
+	return ids
+}
+
+func edgeIDs(edges []Edge) []EdgeID {
+	ids := make([]EdgeID, 0)
+	for _, edge := range edges {
+		ids = append(ids, edge.GetID())
+	}
+	return ids
+}
+
+// buildWeightedGraph builds the path 1 - 2 - 3 - 4 with the shortcut 1 - 3. Node 5 is not connected.
+func buildWeightedGraph(t *testing.T, bo BuilderOptions) Graph {
+	gb := NewGraphBuilder(bo)
+	for i := 1; i < 6; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2, 1.0)
+	gb.AddEdge(2, 3, 1.0)
+	gb.AddEdge(1, 3, 5.0)
+	gb.AddEdge(3, 4, 1.0)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func Test_ShortestPath_Directed(t *testing.T) {
+	graph := buildWeightedGraph(t, BuilderOptions{IsDirected: true})
+	path, err := ShortestPath(graph, 1, 4, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3, 4}, nodeIDs(path.Nodes))
+	assert.Equal(t, []EdgeID{{From: 1, To: 2}, {From: 2, To: 3}, {From: 3, To: 4}}, edgeIDs(path.Edges))
+	assert.Equal(t, 3.0, path.Cost)
+
+	_, err = ShortestPath(graph, 4, 1, valueWeight)
+	assert.ErrorIs(t, err, NoPathFoundError{FromID: 4, ToID: 1})
+
+	_, err = ShortestPath(graph, 1, 6, valueWeight)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 6})
+}
+
+func Test_ShortestPath_Undirected(t *testing.T) {
+	graph := buildWeightedGraph(t, BuilderOptions{})
+	path, err := ShortestPath(graph, 4, 1, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{4, 3, 2, 1}, nodeIDs(path.Nodes))
+	assert.Equal(t, []EdgeID{{From: 3, To: 4}, {From: 2, To: 3}, {From: 1, To: 2}}, edgeIDs(path.Edges))
+	assert.Equal(t, 3.0, path.Cost)
+
+	path, err = ShortestPath(graph, 5, 5, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{5}, nodeIDs(path.Nodes))
+	assert.Equal(t, 0.0, path.Cost)
+}
+
+func Test_ShortestPath_ParallelEdges(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, 4.0)
+	gb.AddEdge(1, 2, 2.0)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	path, err := ShortestPath(graph, 1, 2, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 1, To: 2, Index: 1}}, edgeIDs(path.Edges))
+	assert.Equal(t, 2.0, path.Cost)
+}
+
+func Test_ShortestPath_NegativeWeight(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, -1.0)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = ShortestPath(graph, 1, 2, valueWeight)
+	assert.ErrorIs(t, err, NegativeWeightError{FromID: 1, ToID: 2})
+}
+
+func Test_ShortestPath_InvalidWeight(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, math.NaN())
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = ShortestPath(graph, 1, 2, valueWeight)
+	assert.EqualError(t, err, "edge from 1 to 2 has an invalid weight NaN")
+
+	for _, weight := range []float64{math.Inf(1), math.Inf(-1)} {
+		_, err = AStar(graph, 1, 2, func(e Edge) (float64, error) {
+			return weight, nil
+		}, nil)
+		assert.ErrorIs(t, err, InvalidWeightError{FromID: 1, ToID: 2, Weight: weight})
+	}
+}
+
+func Test_ShortestPaths(t *testing.T) {
+	graph := buildWeightedGraph(t, BuilderOptions{IsDirected: true})
+	spt, err := ShortestPaths(graph, 1, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, map[NodeID]float64{1: 0, 2: 1, 3: 2, 4: 3}, spt.Distances)
+	predecessors := make(map[NodeID]EdgeID)
+	for id, edge := range spt.Predecessors {
+		predecessors[id] = edge.GetID()
+	}
+	assert.Equal(t, map[NodeID]EdgeID{2: {From: 1, To: 2}, 3: {From: 2, To: 3}, 4: {From: 3, To: 4}}, predecessors)
+
+	path, err := spt.PathTo(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 2, 3}, nodeIDs(path.Nodes))
+	_, err = spt.PathTo(5)
+	assert.ErrorIs(t, err, NoPathFoundError{FromID: 1, ToID: 5})
+}
+
+func Test_AStar(t *testing.T) {
+	// 4x4 grid where node id = 4*x + y and the value is its coordinates
+	gb := NewGraphBuilder()
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			gb.AddNode(NodeID(4*x+y), [2]int{x, y})
+		}
+	}
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			if x < 3 {
+				gb.AddEdge(NodeID(4*x+y), NodeID(4*(x+1)+y), 1.0)
+			}
+			if y < 3 {
+				gb.AddEdge(NodeID(4*x+y), NodeID(4*x+y+1), 1.0)
+			}
+		}
+	}
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	manhattan := func(from Node, target Node) (float64, error) {
+		fromValue, err := from.GetValue()
+		if err != nil {
+			return 0, err
+		}
+		targetValue, err := target.GetValue()
+		if err != nil {
+			return 0, err
+		}
+		a, b := fromValue.([2]int), targetValue.([2]int)
+		return math.Abs(float64(a[0]-b[0])) + math.Abs(float64(a[1]-b[1])), nil
+	}
+	path, err := AStar(graph, 0, 15, valueWeight, manhattan)
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, path.Cost)
+	assert.Len(t, path.Nodes, 7)
+	assert.Len(t, path.Edges, 6)
+
+	dijkstraPath, err := ShortestPath(graph, 0, 15, valueWeight)
+	assert.NoError(t, err)
+	assert.Equal(t, dijkstraPath.Cost, path.Cost)
+}
+
+func Test_AStar_InconsistentHeuristic(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 5; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2, 4.0)
+	gb.AddEdge(1, 3, 1.0)
+	gb.AddEdge(3, 2, 1.0)
+	gb.AddEdge(2, 4, 4.0)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	// admissible but not consistent, so node 2 is settled before the cheaper path through 3 is found
+	estimates := map[NodeID]float64{1: 0, 2: 0, 3: 5, 4: 0}
+	heuristic := func(from Node, target Node) (float64, error) {
+		return estimates[from.GetID()], nil
+	}
+
+	path, err := AStar(graph, 1, 4, valueWeight, heuristic)
+	assert.NoError(t, err)
+	actual_cost := 0.0
+	for _, edge := range path.Edges {
+		weight, err := valueWeight(edge)
+		assert.NoError(t, err)
+		actual_cost += weight
+	}
+	assert.Equal(t, path.Cost, actual_cost)
+	assert.Equal(t, NodeID(4), path.Nodes[len(path.Nodes)-1].GetID())
+}
@@ -0,0 +1,69 @@
+package graph
+
+// Tarjan finds the strongly connected components of g in a single depth first search.
+// The components are sorted the same way as the ones returned by Kosaraju.
+func Tarjan(g Graph) ([]StronglyConnectedComponent, error) {
+	sccs := make([]StronglyConnectedComponent, 0)
+	index := make(map[NodeID]int)
+	lowLink := make(map[NodeID]int)
+	onStack := make(map[NodeID]bool)
+	stack := make([]Node, 0)
+	// path holds the nodes currently being explored, the last one being the source of any edge the search follows
+	path := make([]NodeID, 0)
+
+	lowerLink := func(e Edge) (TraversalControl, error) {
+		from := path[len(path)-1]
+		to, err := otherEndpoint(e, from)
+		if err != nil {
+			return Stop, err
+		}
+		if onStack[to] && index[to] < lowLink[from] {
+			lowLink[from] = index[to]
+		}
+		return Continue, nil
+	}
+
+	_, err := DepthFirstSearch(g, DepthFirstSearchOptions{
+		BeforeRecursion: func(n Node) (TraversalControl, error) {
+			index[n.GetID()] = len(index)
+			lowLink[n.GetID()] = index[n.GetID()]
+			onStack[n.GetID()] = true
+			stack = append(stack, n)
+			path = append(path, n.GetID())
+			return Continue, nil
+		},
+		BackEdge:    lowerLink,
+		ForwardEdge: lowerLink,
+		CrossEdge:   lowerLink,
+		AfterRecursion: func(n Node) (TraversalControl, error) {
+			id := n.GetID()
+			path = path[:len(path)-1]
+			// every edge of an undirected graph can be followed both ways, so only a root closes a component
+			if lowLink[id] == index[id] && (g.IsDirected() || len(path) == 0) {
+				scc := make(StronglyConnectedComponent, 0)
+				for {
+					top := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[top.GetID()] = false
+					scc = append(scc, top)
+					if top.GetID() == id {
+						break
+					}
+				}
+				sccs = append(sccs, scc)
+			}
+			if len(path) > 0 {
+				parent := path[len(path)-1]
+				if lowLink[id] < lowLink[parent] {
+					lowLink[parent] = lowLink[id]
+				}
+			}
+			return Continue, nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortComponents(sccs)
+	return sccs, nil
+}
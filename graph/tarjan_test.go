@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Tarjan_Directed(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 9; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	// cycle of 1, 2, 3, 4
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 4)
+	gb.AddEdge(4, 1)
+
+	// this edge connects two cycles
+	gb.AddEdge(3, 5)
+
+	// cycle of 5, 6, 7
+	gb.AddEdge(5, 6)
+	gb.AddEdge(6, 7)
+	gb.AddEdge(7, 5)
+
+	// this edge connects the prev cycle to a single node
+	gb.AddEdge(7, 8)
+
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_sccs, err := Tarjan(graph)
+	assert.NoError(t, err)
+	expected_sccs, err := Kosaraju(graph)
+	assert.NoError(t, err)
+	AssertSCCsEquals(t, expected_sccs, actual_sccs)
+}
+
+func Test_Tarjan_CrossEdges(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true, AllowRedundantEdges: true})
+	for i := 1; i < 7; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 1)
+	gb.AddEdge(1, 3)
+	gb.AddEdge(3, 4)
+	gb.AddEdge(4, 3)
+	gb.AddEdge(5, 3)
+	gb.AddEdge(5, 6)
+	gb.AddEdge(6, 5)
+	gb.AddEdge(6, 6)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_sccs, err := Tarjan(graph)
+	assert.NoError(t, err)
+	expected_sccs, err := Kosaraju(graph)
+	assert.NoError(t, err)
+	AssertSCCsEquals(t, expected_sccs, actual_sccs)
+	assert.Len(t, actual_sccs, 3)
+}
+
+func Test_Tarjan_Undirected(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 6; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(1, 3)
+	gb.AddEdge(4, 5)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_sccs, err := Tarjan(graph)
+	assert.NoError(t, err)
+	expected_sccs := []StronglyConnectedComponent{
+		{
+			rawUndirectedNode{ID: 4, Neighbors: []NodeID{5}},
+			rawUndirectedNode{ID: 5, Neighbors: []NodeID{4}},
+		},
+		{
+			rawUndirectedNode{ID: 1, Neighbors: []NodeID{2, 3}},
+			rawUndirectedNode{ID: 2, Neighbors: []NodeID{1, 3}},
+			rawUndirectedNode{ID: 3, Neighbors: []NodeID{1, 2}},
+		},
+	}
+	AssertSCCsEquals(t, expected_sccs, actual_sccs)
+}
+
+// failingGraph is a graph whose nodes cannot be listed.
+type failingGraph struct {
+	Graph
+	err error
+}
+
+func (fg failingGraph) GetNodes() ([]Node, error) {
+	return nil, fg.err
+}
+
+func Test_Tarjan_Error(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	expected_error := errors.New("nodes unavailable")
+	_, err = Tarjan(failingGraph{Graph: graph, err: expected_error})
+	assert.ErrorIs(t, err, expected_error)
+	_, err = Condensation(failingGraph{Graph: graph, err: expected_error})
+	assert.ErrorIs(t, err, expected_error)
+}
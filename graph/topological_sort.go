@@ -0,0 +1,123 @@
+package graph
+
+import "container/heap"
+
+// TopologicalSort orders the nodes of a directed graph so that every edge goes from an earlier node to a later one.
+// It uses Kahn's algorithm and always picks the smallest id among the nodes that are ready,
+// so the order is the same on every call.
+// If the graph has a cycle then this returns a CycleError holding one of its cycles.
+func TopologicalSort(g Graph) ([]Node, error) {
+	if !g.IsDirected() {
+		return nil, CannotUseForUndirectedGraphError{MethodName: "TopologicalSort"}
+	}
+	allNodes, err := g.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	inDegrees := make(map[NodeID]int)
+	queue := &nodeQueue{}
+	for _, node := range allNodes {
+		incoming, err := node.GetIncomingEdges()
+		if err != nil {
+			return nil, err
+		}
+		inDegrees[node.GetID()] = len(incoming)
+		if len(incoming) == 0 {
+			heap.Push(queue, queuedNode{Node: node})
+		}
+	}
+
+	order := make([]Node, 0)
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(queuedNode).Node
+		order = append(order, current)
+		adjacent, err := getAdjacentEdges(current, true, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, next := range adjacent {
+			inDegrees[next.To]--
+			if inDegrees[next.To] == 0 {
+				nextNode, err := g.GetNode(next.To)
+				if err != nil {
+					return nil, err
+				}
+				heap.Push(queue, queuedNode{Node: nextNode})
+			}
+		}
+	}
+	if len(order) == len(allNodes) {
+		return order, nil
+	}
+
+	remaining := make(map[NodeID]bool)
+	for id, inDegree := range inDegrees {
+		if inDegree > 0 {
+			remaining[id] = true
+		}
+	}
+	cycle, err := findCycle(g, remaining)
+	if err != nil {
+		return nil, err
+	}
+	return nil, CycleError{NodeIDs: cycle}
+}
+
+// findCycle returns a cycle among the remaining nodes of a failed topological sort.
+// Every remaining node has an incoming edge from another remaining node,
+// so walking those edges backwards from any of them must run into a cycle.
+// The cycle starts with its smallest id and follows the direction of the edges.
+func findCycle(g Graph, remaining map[NodeID]bool) ([]NodeID, error) {
+	var current NodeID
+	first := true
+	for id := range remaining {
+		if first || id < current {
+			current = id
+			first = false
+		}
+	}
+	walk := make([]NodeID, 0)
+	position := make(map[NodeID]int)
+	for {
+		if start, seen := position[current]; seen {
+			walk = walk[start:]
+			break
+		}
+		position[current] = len(walk)
+		walk = append(walk, current)
+		node, err := g.GetNode(current)
+		if err != nil {
+			return nil, err
+		}
+		adjacent, err := getAdjacentEdges(node, true, true)
+		if err != nil {
+			return nil, err
+		}
+		// incoming edges are sorted, so this picks the smallest remaining predecessor
+		for _, previous := range adjacent {
+			if remaining[previous.To] {
+				current = previous.To
+				break
+			}
+		}
+	}
+
+	// the walk went against the edges, so reverse it and rotate it to start with its smallest id
+	smallest := 0
+	for i := range walk {
+		if walk[i] < walk[smallest] {
+			smallest = i
+		}
+	}
+	cycle := make([]NodeID, 0, len(walk))
+	for i := 0; i < len(walk); i++ {
+		cycle = append(cycle, walk[(smallest-i+len(walk))%len(walk)])
+	}
+	return cycle, nil
+}
+
+// IsDAG reports whether g is a directed graph without cycles.
+func IsDAG(g Graph) bool {
+	_, err := TopologicalSort(g)
+	return err == nil
+}
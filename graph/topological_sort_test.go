@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TopologicalSort(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true, AllowParallelEdges: true})
+	for i := 1; i < 7; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(5, 2)
+	gb.AddEdge(5, 2)
+	gb.AddEdge(2, 1)
+	gb.AddEdge(6, 1)
+	gb.AddEdge(3, 4)
+	gb.AddEdge(4, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	actual_order, err := TopologicalSort(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{3, 4, 5, 2, 6, 1}, nodeIDs(actual_order))
+	assert.True(t, IsDAG(graph))
+}
+
+func Test_TopologicalSort_Cycle(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 7; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	// 1 leads into the cycle 4 -> 2 -> 5 -> 4, which leads out to 3 and 6
+	gb.AddEdge(1, 4)
+	gb.AddEdge(4, 2)
+	gb.AddEdge(2, 5)
+	gb.AddEdge(5, 4)
+	gb.AddEdge(5, 3)
+	gb.AddEdge(3, 6)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = TopologicalSort(graph)
+	var cycleError CycleError
+	assert.True(t, errors.As(err, &cycleError))
+	assert.Equal(t, []NodeID{2, 5, 4}, cycleError.NodeIDs)
+	assert.False(t, IsDAG(graph))
+}
+
+func Test_TopologicalSort_SelfLoop(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true, AllowRedundantEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 2)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = TopologicalSort(graph)
+	var cycleError CycleError
+	assert.True(t, errors.As(err, &cycleError))
+	assert.Equal(t, []NodeID{2}, cycleError.NodeIDs)
+}
+
+func Test_TopologicalSort_Undirected(t *testing.T) {
+	gb := NewGraphBuilder()
+	gb.AddNode(1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	_, err = TopologicalSort(graph)
+	assert.ErrorIs(t, err, CannotUseForUndirectedGraphError{MethodName: "TopologicalSort"})
+	assert.False(t, IsDAG(graph))
+}
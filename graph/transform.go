@@ -0,0 +1,378 @@
+package graph
+
+import "sort"
+
+// MergeOptions tell the graph operators how to combine the values of nodes or edges that collide.
+// If only one of the colliding nodes or edges has a value then that value is kept.
+// If a function is nil then the value from the second graph is kept,
+// just like a builder that allows duplicates keeps the last value.
+type MergeOptions[N, E any] struct {
+	// MergeNodes combines the values of two nodes with the same id.
+	MergeNodes func(a N, b N) N
+	// MergeEdges combines the values of two edges with the same id.
+	MergeEdges func(a E, b E) E
+}
+
+func getMergeOptions[N, E any](mo []MergeOptions[N, E]) MergeOptions[N, E] {
+	if len(mo) == 1 {
+		return mo[0]
+	}
+	return MergeOptions[N, E]{}
+}
+
+// transformBuilder creates the builder every operator uses,
+// which keeps the parallel edges and self-loops of the graphs it is given.
+func transformBuilder[N, E any](isDirected bool, storage StorageBackend) *graphBuilder[N, E] {
+	return NewTypedGraphBuilder[N, E](BuilderOptions{
+		AllowParallelEdges:  true,
+		AllowRedundantEdges: true,
+		IsDirected:          isDirected,
+		Storage:             storage,
+	}).(*graphBuilder[N, E])
+}
+
+// storageOf returns the storage backend g was built with. Graphs that were not built, such as views, use MapStorage.
+func storageOf[N, E any](g TypedGraph[N, E]) StorageBackend {
+	if _, isCSR := g.(*csrGraph[N, E]); isCSR {
+		return CSRStorage
+	}
+	return MapStorage
+}
+
+func nodeValue[N, E any](node TypedNode[N, E]) typedValue[N] {
+	value, err := node.GetValue()
+	if err != nil {
+		return typedValue[N]{}
+	}
+	return typedValue[N]{HasValue: true, RawValue: value}
+}
+
+func edgeValue[N, E any](edge TypedEdge[N, E]) typedValue[E] {
+	value, err := edge.GetValue()
+	if err != nil {
+		return typedValue[E]{}
+	}
+	return typedValue[E]{HasValue: true, RawValue: value}
+}
+
+// values turns the value back into the optional argument of AddNode and AddEdge.
+func (tv typedValue[T]) values() []T {
+	if !tv.HasValue {
+		return nil
+	}
+	return []T{tv.RawValue}
+}
+
+func mergeValues[T any](a, b typedValue[T], merge func(T, T) T) typedValue[T] {
+	if !a.HasValue {
+		return b
+	}
+	if !b.HasValue {
+		return a
+	}
+	if merge == nil {
+		return b
+	}
+	return typedValue[T]{HasValue: true, RawValue: merge(a.RawValue, b.RawValue)}
+}
+
+// nodeValues maps the id of every node of g to its value.
+func nodeValues[N, E any](g TypedGraph[N, E]) (map[NodeID]typedValue[N], error) {
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[NodeID]typedValue[N])
+	for _, node := range nodes {
+		values[node.GetID()] = nodeValue(node)
+	}
+	return values, nil
+}
+
+// edgeValues maps the id of every edge of g to its value.
+func edgeValues[N, E any](g TypedGraph[N, E]) (map[EdgeID]typedValue[E], error) {
+	edges, err := g.GetEdges()
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[EdgeID]typedValue[E])
+	for _, edge := range edges {
+		values[edge.GetID()] = edgeValue(edge)
+	}
+	return values, nil
+}
+
+// buildFromValues builds a graph out of the given nodes and edges, which keep their ids, with the given storage backend.
+// The ids of undirected edges must have From <= To.
+func buildFromValues[N, E any](isDirected bool, storage StorageBackend, nodes map[NodeID]typedValue[N], edges map[EdgeID]typedValue[E]) (TypedGraph[N, E], error) {
+	builder := transformBuilder[N, E](isDirected, storage)
+	for id, value := range nodes {
+		builder.AddNode(id, value.values()...)
+	}
+	ids := make([]EdgeID, 0, len(edges))
+	for id := range edges {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return lessEdgeID(ids[i], ids[j])
+	})
+	// the ids are sorted, so the parallel edges between two nodes are added in ascending order of index
+	for _, id := range ids {
+		builder.addEdgeWithIndex(id.From, id.To, id.Index, edges[id].values()...)
+	}
+	return builder.Build()
+}
+
+// Reverse returns the transpose of g, where every directed edge points the other way.
+// Parallel edges keep their index. An undirected graph is its own transpose, so it is simply copied.
+func Reverse[N, E any](g TypedGraph[N, E]) (TypedGraph[N, E], error) {
+	nodes, err := nodeValues(g)
+	if err != nil {
+		return nil, err
+	}
+	edges, err := edgeValues(g)
+	if err != nil {
+		return nil, err
+	}
+	if !g.IsDirected() {
+		return buildFromValues(false, storageOf(g), nodes, edges)
+	}
+	reversed := make(map[EdgeID]typedValue[E])
+	for id, value := range edges {
+		reversed[EdgeID{From: id.To, To: id.From, Index: id.Index}] = value
+	}
+	return buildFromValues(true, storageOf(g), nodes, reversed)
+}
+
+// ToUndirected returns g with the direction of every edge dropped.
+// The edges a -> b and b -> a with the same index collide into one undirected edge,
+// whose value is merged with the value of a -> b first when a is less than b.
+// An undirected graph is simply copied.
+func ToUndirected[N, E any](g TypedGraph[N, E], mo ...MergeOptions[N, E]) (TypedGraph[N, E], error) {
+	nodes, err := nodeValues(g)
+	if err != nil {
+		return nil, err
+	}
+	edges, err := g.GetEdges()
+	if err != nil {
+		return nil, err
+	}
+	mergeOptions := getMergeOptions(mo)
+	undirected := make(map[EdgeID]typedValue[E])
+	// GetEdges sorts by from id, so a -> b is visited before b -> a when a is less than b
+	for _, edge := range edges {
+		id := edge.GetID()
+		if id.From > id.To {
+			id.From, id.To = id.To, id.From
+		}
+		if existing, collides := undirected[id]; collides {
+			undirected[id] = mergeValues(existing, edgeValue(edge), mergeOptions.MergeEdges)
+		} else {
+			undirected[id] = edgeValue(edge)
+		}
+	}
+	return buildFromValues(false, storageOf(g), nodes, undirected)
+}
+
+// ToDirected returns g with every undirected edge a - b replaced by the two edges a -> b and b -> a,
+// both with the value and index of the original edge. A self-loop is replaced by a single edge.
+// A directed graph is simply copied.
+func ToDirected[N, E any](g TypedGraph[N, E]) (TypedGraph[N, E], error) {
+	nodes, err := nodeValues(g)
+	if err != nil {
+		return nil, err
+	}
+	edges, err := edgeValues(g)
+	if err != nil {
+		return nil, err
+	}
+	directed := make(map[EdgeID]typedValue[E])
+	for id, value := range edges {
+		directed[id] = value
+		if !g.IsDirected() {
+			directed[EdgeID{From: id.To, To: id.From, Index: id.Index}] = value
+		}
+	}
+	return buildFromValues(true, storageOf(g), nodes, directed)
+}
+
+// Complement returns a graph with the same nodes as g and an edge between every pair of distinct nodes
+// that are not adjacent in g. In a directed graph, a -> b is added if g has no edge a -> b.
+// The nodes keep their values but the new edges have none.
+func Complement[N, E any](g TypedGraph[N, E]) (TypedGraph[N, E], error) {
+	builder := NewTypedGraphBuilder[N, E](BuilderOptions{IsDirected: g.IsDirected(), Storage: storageOf(g)})
+	nodes, err := g.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		builder.AddNode(node.GetID(), nodeValue(node).values()...)
+	}
+	for i, from := range nodes {
+		for j, to := range nodes {
+			if i == j || (!g.IsDirected() && j < i) {
+				continue
+			}
+			if _, err := g.GetEdge(from.GetID(), to.GetID()); err == nil {
+				continue
+			}
+			builder.AddEdge(from.GetID(), to.GetID())
+		}
+	}
+	return builder.Build()
+}
+
+// Union returns a graph with every node and edge of a and b.
+// Nodes with the same id and edges with the same EdgeID collide and have their values merged.
+// Every edge keeps its EdgeID, so the indices of parallel edges can have gaps.
+// Both graphs must be directed or both must be undirected.
+func Union[N, E any](a, b TypedGraph[N, E], mo ...MergeOptions[N, E]) (TypedGraph[N, E], error) {
+	return combine(a, b, getMergeOptions(mo), false)
+}
+
+// Intersection returns a graph with the nodes and edges that are both in a and b.
+// Nodes are matched by id and edges by EdgeID, and their values are merged.
+// Every edge keeps its EdgeID, so the indices of parallel edges can have gaps.
+// Both graphs must be directed or both must be undirected.
+func Intersection[N, E any](a, b TypedGraph[N, E], mo ...MergeOptions[N, E]) (TypedGraph[N, E], error) {
+	return combine(a, b, getMergeOptions(mo), true)
+}
+
+// combine merges a and b into a new graph and drops what is not in both of them if intersect is set.
+func combine[N, E any](a, b TypedGraph[N, E], mergeOptions MergeOptions[N, E], intersect bool) (TypedGraph[N, E], error) {
+	if a.IsDirected() != b.IsDirected() {
+		return nil, ErrMixedDirectedness
+	}
+	aNodes, err := nodeValues(a)
+	if err != nil {
+		return nil, err
+	}
+	bNodes, err := nodeValues(b)
+	if err != nil {
+		return nil, err
+	}
+	aEdges, err := edgeValues(a)
+	if err != nil {
+		return nil, err
+	}
+	bEdges, err := edgeValues(b)
+	if err != nil {
+		return nil, err
+	}
+	return buildFromValues(a.IsDirected(), storageOf(a),
+		combineValues(aNodes, bNodes, mergeOptions.MergeNodes, intersect),
+		combineValues(aEdges, bEdges, mergeOptions.MergeEdges, intersect))
+}
+
+func combineValues[K comparable, T any](a, b map[K]typedValue[T], merge func(T, T) T, intersect bool) map[K]typedValue[T] {
+	combined := make(map[K]typedValue[T])
+	for key, aValue := range a {
+		if bValue, inBoth := b[key]; inBoth {
+			combined[key] = mergeValues(aValue, bValue, merge)
+		} else if !intersect {
+			combined[key] = aValue
+		}
+	}
+	if !intersect {
+		for key, bValue := range b {
+			if _, inA := a[key]; !inA {
+				combined[key] = bValue
+			}
+		}
+	}
+	return combined
+}
+
+// ProductGraph is the cartesian product of two graphs.
+// Every node is a pair of a node from the first graph and a node from the second graph.
+// The pair (a, b) gets the id i * len(b nodes) + j where i and j are the positions of a and b in GetNodes,
+// so NodeOf and PairOf translate between the ids of the pairs and the ids of the nodes they were made from.
+type ProductGraph[N, E any] struct {
+	TypedGraph[N, E]
+	ids   map[NodeID]map[NodeID]NodeID
+	pairs map[NodeID][2]NodeID
+}
+
+// NodeOf returns the id of the node made from node a of the first graph and node b of the second graph.
+// If either node is not in its graph then this returns a "node not found" error.
+func (pg ProductGraph[N, E]) NodeOf(a NodeID, b NodeID) (NodeID, error) {
+	ids, ok := pg.ids[a]
+	if !ok {
+		return 0, NodeNotFoundError{NodeID: a}
+	}
+	id, ok := ids[b]
+	if !ok {
+		return 0, NodeNotFoundError{NodeID: b}
+	}
+	return id, nil
+}
+
+// PairOf returns the ids of the nodes of the first and second graph that the node with the given id was made from.
+// If the node is not in the product then this returns a "node not found" error.
+func (pg ProductGraph[N, E]) PairOf(id NodeID) (NodeID, NodeID, error) {
+	pair, ok := pg.pairs[id]
+	if !ok {
+		return 0, 0, NodeNotFoundError{NodeID: id}
+	}
+	return pair[0], pair[1], nil
+}
+
+// CartesianProduct returns the cartesian product of a and b.
+// There is an edge from (u, v) to (u', v) for every edge from u to u' in a
+// and an edge from (u, v) to (u, v') for every edge from v to v' in b; each keeps the value of its edge.
+// The value of (u, v) is the value of u merged with the value of v.
+// Both graphs must be directed or both must be undirected.
+func CartesianProduct[N, E any](a, b TypedGraph[N, E], mo ...MergeOptions[N, E]) (ProductGraph[N, E], error) {
+	if a.IsDirected() != b.IsDirected() {
+		return ProductGraph[N, E]{}, ErrMixedDirectedness
+	}
+	aNodes, err := a.GetNodes()
+	if err != nil {
+		return ProductGraph[N, E]{}, err
+	}
+	bNodes, err := b.GetNodes()
+	if err != nil {
+		return ProductGraph[N, E]{}, err
+	}
+	mergeOptions := getMergeOptions(mo)
+	builder := transformBuilder[N, E](a.IsDirected(), storageOf(a))
+	ids := make(map[NodeID]map[NodeID]NodeID)
+	pairs := make(map[NodeID][2]NodeID)
+	for i, aNode := range aNodes {
+		ids[aNode.GetID()] = make(map[NodeID]NodeID)
+		for j, bNode := range bNodes {
+			id := NodeID(i*len(bNodes) + j)
+			ids[aNode.GetID()][bNode.GetID()] = id
+			pairs[id] = [2]NodeID{aNode.GetID(), bNode.GetID()}
+			value := mergeValues(nodeValue(aNode), nodeValue(bNode), mergeOptions.MergeNodes)
+			builder.AddNode(id, value.values()...)
+		}
+	}
+
+	aEdges, err := a.GetEdges()
+	if err != nil {
+		return ProductGraph[N, E]{}, err
+	}
+	for _, edge := range aEdges {
+		id := edge.GetID()
+		for _, bNode := range bNodes {
+			builder.AddEdge(ids[id.From][bNode.GetID()], ids[id.To][bNode.GetID()], edgeValue(edge).values()...)
+		}
+	}
+	bEdges, err := b.GetEdges()
+	if err != nil {
+		return ProductGraph[N, E]{}, err
+	}
+	for _, edge := range bEdges {
+		id := edge.GetID()
+		for _, aNode := range aNodes {
+			builder.AddEdge(ids[aNode.GetID()][id.From], ids[aNode.GetID()][id.To], edgeValue(edge).values()...)
+		}
+	}
+
+	product, err := builder.Build()
+	if err != nil {
+		return ProductGraph[N, E]{}, err
+	}
+	return ProductGraph[N, E]{TypedGraph: product, ids: ids, pairs: pairs}, nil
+}
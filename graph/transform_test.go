@@ -0,0 +1,358 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// allEdgeIDs returns the ids of every edge of g.
+func allEdgeIDs(t *testing.T, g Graph) []EdgeID {
+	edges, err := g.GetEdges()
+	assert.NoError(t, err)
+	return edgeIDs(edges)
+}
+
+func Test_Reverse(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true, AllowParallelEdges: true})
+	for i := 1; i < 4; i++ {
+		gb.AddNode(NodeID(i), i*10)
+	}
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(1, 2, "b")
+	gb.AddEdge(2, 3, "c")
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	reversed, err := Reverse(graph)
+	assert.NoError(t, err)
+	assert.True(t, reversed.IsDirected())
+	assert.Equal(t, []EdgeID{{From: 2, To: 1}, {From: 2, To: 1, Index: 1}, {From: 3, To: 2}}, allEdgeIDs(t, reversed))
+	edges, err := reversed.GetEdgesBetween(2, 1)
+	assert.NoError(t, err)
+	value, err := edges[1].GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", value)
+	node, err := reversed.GetNode(3)
+	assert.NoError(t, err)
+	value, err = node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 30, value)
+
+	// depth first search on the transpose matches the ReverseGraph option
+	expected_order := make([]NodeID, 0)
+	_, err = DepthFirstSearch(graph, DepthFirstSearchOptions{ReverseGraph: true, BeforeRecursion: recordNodes(&expected_order)})
+	assert.NoError(t, err)
+	actual_order := make([]NodeID, 0)
+	_, err = DepthFirstSearch(reversed, DepthFirstSearchOptions{BeforeRecursion: recordNodes(&actual_order)})
+	assert.NoError(t, err)
+	assert.Equal(t, expected_order, actual_order)
+
+	// the reverse of the reverse is the original graph
+	original, err := Reverse(reversed)
+	assert.NoError(t, err)
+	AssertGraphEquals(t, graph, original)
+}
+
+func Test_ToUndirected(t *testing.T) {
+	gb := NewTypedGraphBuilder[string, int](BuilderOptions{IsDirected: true})
+	for i := 1; i < 4; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2, 1)
+	gb.AddEdge(2, 1, 2)
+	gb.AddEdge(3, 2, 4)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	undirected, err := ToUndirected(graph, MergeOptions[string, int]{
+		MergeEdges: func(a int, b int) int { return a*10 + b },
+	})
+	assert.NoError(t, err)
+	assert.False(t, undirected.IsDirected())
+	actual_edges, err := edgeValues(undirected)
+	assert.NoError(t, err)
+	expected_edges := map[EdgeID]typedValue[int]{
+		{From: 1, To: 2}: {HasValue: true, RawValue: 12},
+		{From: 2, To: 3}: {HasValue: true, RawValue: 4},
+	}
+	assert.Equal(t, expected_edges, actual_edges)
+
+	// without a merge function the edge from the larger id wins
+	undirected, err = ToUndirected(graph)
+	assert.NoError(t, err)
+	edge, err := undirected.GetEdge(2, 1)
+	assert.NoError(t, err)
+	value, err := edge.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, value)
+}
+
+func Test_ToDirected(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowRedundantEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(2, 2, "b")
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+
+	directed, err := ToDirected(graph)
+	assert.NoError(t, err)
+	assert.True(t, directed.IsDirected())
+	actual_edges, err := edgeValues(directed)
+	assert.NoError(t, err)
+	expected_edges := map[EdgeID]wrappedValue{
+		{From: 1, To: 2}: {HasValue: true, RawValue: "a"},
+		{From: 2, To: 1}: {HasValue: true, RawValue: "a"},
+		{From: 2, To: 2}: {HasValue: true, RawValue: "b"},
+	}
+	assert.Equal(t, expected_edges, actual_edges)
+
+	// going back to undirected merges the two arcs back into one edge
+	undirected, err := ToUndirected(directed)
+	assert.NoError(t, err)
+	AssertGraphEquals(t, graph, undirected)
+}
+
+func Test_Complement(t *testing.T) {
+	gb := NewGraphBuilder()
+	for i := 1; i < 5; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 3)
+	gb.AddEdge(3, 4)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	complement, err := Complement(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 1, To: 3}, {From: 1, To: 4}, {From: 2, To: 4}}, allEdgeIDs(t, complement))
+
+	gb = NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 4; i++ {
+		gb.AddNode(NodeID(i))
+	}
+	gb.AddEdge(1, 2)
+	gb.AddEdge(2, 1)
+	gb.AddEdge(2, 3)
+	graph, err = gb.Build()
+	assert.NoError(t, err)
+	complement, err = Complement(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{{From: 1, To: 3}, {From: 3, To: 1}, {From: 3, To: 2}}, allEdgeIDs(t, complement))
+}
+
+func buildOperands(t *testing.T) (TypedGraph[int, int], TypedGraph[int, int]) {
+	gb := NewTypedGraphBuilder[int, int]()
+	gb.AddNode(1, 1)
+	gb.AddNode(2, 2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2, 1)
+	gb.AddEdge(2, 3, 2)
+	a, err := gb.Build()
+	assert.NoError(t, err)
+
+	gb = NewTypedGraphBuilder[int, int]()
+	gb.AddNode(2, 20)
+	gb.AddNode(3, 30)
+	gb.AddNode(4, 40)
+	gb.AddEdge(3, 2, 20)
+	gb.AddEdge(3, 4, 30)
+	b, err := gb.Build()
+	assert.NoError(t, err)
+	return a, b
+}
+
+func Test_Union(t *testing.T) {
+	a, b := buildOperands(t)
+	sum := MergeOptions[int, int]{
+		MergeNodes: func(a int, b int) int { return a + b },
+		MergeEdges: func(a int, b int) int { return a + b },
+	}
+	union, err := Union(a, b, sum)
+	assert.NoError(t, err)
+	actual_nodes, err := nodeValues(union)
+	assert.NoError(t, err)
+	expected_nodes := map[NodeID]typedValue[int]{
+		1: {HasValue: true, RawValue: 1},
+		2: {HasValue: true, RawValue: 22},
+		3: {HasValue: true, RawValue: 30},
+		4: {HasValue: true, RawValue: 40},
+	}
+	assert.Equal(t, expected_nodes, actual_nodes)
+	actual_edges, err := edgeValues(union)
+	assert.NoError(t, err)
+	expected_edges := map[EdgeID]typedValue[int]{
+		{From: 1, To: 2}: {HasValue: true, RawValue: 1},
+		{From: 2, To: 3}: {HasValue: true, RawValue: 22},
+		{From: 3, To: 4}: {HasValue: true, RawValue: 30},
+	}
+	assert.Equal(t, expected_edges, actual_edges)
+
+	// without merge functions the values of b win
+	union, err = Union(a, b)
+	assert.NoError(t, err)
+	node, err := union.GetNode(2)
+	assert.NoError(t, err)
+	value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, value)
+}
+
+func Test_Intersection(t *testing.T) {
+	a, b := buildOperands(t)
+	intersection, err := Intersection(a, b, MergeOptions[int, int]{
+		MergeEdges: func(a int, b int) int { return a * b },
+	})
+	assert.NoError(t, err)
+	actual_nodes, err := nodeValues(intersection)
+	assert.NoError(t, err)
+	expected_nodes := map[NodeID]typedValue[int]{
+		2: {HasValue: true, RawValue: 20},
+		3: {HasValue: true, RawValue: 30},
+	}
+	assert.Equal(t, expected_nodes, actual_nodes)
+	actual_edges, err := edgeValues(intersection)
+	assert.NoError(t, err)
+	assert.Equal(t, map[EdgeID]typedValue[int]{{From: 2, To: 3}: {HasValue: true, RawValue: 40}}, actual_edges)
+}
+
+func Test_Transforms_KeepEdgeIndices(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{AllowParallelEdges: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(1, 2, "b")
+	gb.AddEdge(1, 2, "c")
+	mutable, err := gb.BuildMutable()
+	assert.NoError(t, err)
+	assert.NoError(t, mutable.RemoveEdgeByID(EdgeID{From: 1, To: 2, Index: 0}))
+	var graph Graph = mutable
+	expected_ids := []EdgeID{{From: 1, To: 2, Index: 1}, {From: 1, To: 2, Index: 2}}
+
+	reversed, err := Reverse(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, expected_ids, allEdgeIDs(t, reversed))
+	union, err := Union(graph, graph)
+	assert.NoError(t, err)
+	assert.Equal(t, expected_ids, allEdgeIDs(t, union))
+	intersection, err := Intersection(graph, graph)
+	assert.NoError(t, err)
+	assert.Equal(t, expected_ids, allEdgeIDs(t, intersection))
+	edge, err := intersection.GetEdgesBetween(2, 1)
+	assert.NoError(t, err)
+	value, err := edge[1].GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "c", value)
+
+	directed, err := ToDirected(graph)
+	assert.NoError(t, err)
+	assert.Equal(t, []EdgeID{
+		{From: 1, To: 2, Index: 1}, {From: 1, To: 2, Index: 2},
+		{From: 2, To: 1, Index: 1}, {From: 2, To: 1, Index: 2},
+	}, allEdgeIDs(t, directed))
+	undirected, err := ToUndirected(directed)
+	assert.NoError(t, err)
+	assert.Equal(t, expected_ids, allEdgeIDs(t, undirected))
+}
+
+func Test_Transforms_KeepStorage(t *testing.T) {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true, AllowParallelEdges: true, Storage: CSRStorage})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(1, 2, "b")
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	mutable, err := Edit(graph)
+	assert.NoError(t, err)
+	assert.NoError(t, mutable.RemoveEdgeByID(EdgeID{From: 1, To: 2, Index: 0}))
+
+	reversed, err := Reverse(graph)
+	assert.NoError(t, err)
+	assert.IsType(t, &csrGraph[interface{}, interface{}]{}, reversed)
+	intersection, err := Intersection(graph, Graph(mutable))
+	assert.NoError(t, err)
+	assert.IsType(t, &csrGraph[interface{}, interface{}]{}, intersection)
+	edges, err := intersection.GetEdges()
+	assert.NoError(t, err)
+	assert.Len(t, edges, 1)
+	assert.Equal(t, EdgeID{From: 1, To: 2, Index: 1}, edges[0].GetID())
+	value, err := edges[0].GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", value)
+
+	// a view is not built, so the result is stored in maps
+	union, err := Union(FilteredView(graph, nil, nil), graph)
+	assert.NoError(t, err)
+	assert.IsType(t, directedGraph[interface{}, interface{}]{}, union)
+}
+
+func Test_Combine_MixedDirectedness(t *testing.T) {
+	a, err := NewGraphBuilder().Build()
+	assert.NoError(t, err)
+	b, err := NewGraphBuilder(BuilderOptions{IsDirected: true}).Build()
+	assert.NoError(t, err)
+	_, err = Union(a, b)
+	assert.ErrorIs(t, err, ErrMixedDirectedness)
+	_, err = Intersection(a, b)
+	assert.ErrorIs(t, err, ErrMixedDirectedness)
+	_, err = CartesianProduct(a, b)
+	assert.ErrorIs(t, err, ErrMixedDirectedness)
+}
+
+func Test_CartesianProduct(t *testing.T) {
+	// the product of a path of 3 nodes and a path of 2 nodes is a 3 by 2 grid
+	gb := NewTypedGraphBuilder[string, string]()
+	gb.AddNode(7, "a")
+	gb.AddNode(8, "b")
+	gb.AddNode(9, "c")
+	gb.AddEdge(7, 8, "ab")
+	gb.AddEdge(8, 9, "bc")
+	path, err := gb.Build()
+	assert.NoError(t, err)
+	gb = NewTypedGraphBuilder[string, string]()
+	gb.AddNode(1, "x")
+	gb.AddNode(2, "y")
+	gb.AddEdge(1, 2, "xy")
+	edge, err := gb.Build()
+	assert.NoError(t, err)
+
+	product, err := CartesianProduct(path, edge, MergeOptions[string, string]{
+		MergeNodes: func(a string, b string) string { return a + b },
+	})
+	assert.NoError(t, err)
+	nodes, err := product.GetNodes()
+	assert.NoError(t, err)
+	assert.Len(t, nodes, 6)
+	id, err := product.NodeOf(8, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, NodeID(3), id)
+	a, b, err := product.PairOf(id)
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{8, 2}, []NodeID{a, b})
+	node, err := product.GetNode(id)
+	assert.NoError(t, err)
+	value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "by", value)
+
+	actual_edges, err := edgeValues(product.TypedGraph)
+	assert.NoError(t, err)
+	expected_edges := map[EdgeID]typedValue[string]{
+		{From: 0, To: 2}: {HasValue: true, RawValue: "ab"},
+		{From: 1, To: 3}: {HasValue: true, RawValue: "ab"},
+		{From: 2, To: 4}: {HasValue: true, RawValue: "bc"},
+		{From: 3, To: 5}: {HasValue: true, RawValue: "bc"},
+		{From: 0, To: 1}: {HasValue: true, RawValue: "xy"},
+		{From: 2, To: 3}: {HasValue: true, RawValue: "xy"},
+		{From: 4, To: 5}: {HasValue: true, RawValue: "xy"},
+	}
+	assert.Equal(t, expected_edges, actual_edges)
+
+	_, err = product.NodeOf(8, 3)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 3})
+	_, _, err = product.PairOf(6)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 6})
+}
@@ -0,0 +1,167 @@
+package graph
+
+// Untyped wraps a typed graph so it can be passed to functions that take a Graph,
+// such as DepthFirstSearch and Kosaraju. Node and edge values are returned as interface{}.
+// The wrapper reads through to g, so it does not copy any nodes or edges.
+func Untyped[N, E any](g TypedGraph[N, E]) Graph {
+	if untyped, isUntyped := interface{}(g).(Graph); isUntyped {
+		return untyped
+	}
+	return untypedGraph[N, E]{Typed: g}
+}
+
+type untypedGraph[N, E any] struct {
+	Typed TypedGraph[N, E]
+}
+
+func untypeNodes[N, E any](typedNodes []TypedNode[N, E]) []Node {
+	nodes := make([]Node, 0)
+	for _, typedNode := range typedNodes {
+		nodes = append(nodes, untypedNode[N, E]{Typed: typedNode})
+	}
+	return nodes
+}
+
+func untypeEdges[N, E any](typedEdges []TypedEdge[N, E]) []Edge {
+	edges := make([]Edge, 0)
+	for _, typedEdge := range typedEdges {
+		edges = append(edges, untypedEdge[N, E]{Typed: typedEdge})
+	}
+	return edges
+}
+
+func (ug untypedGraph[N, E]) GetNode(id NodeID) (Node, error) {
+	node, err := ug.Typed.GetNode(id)
+	if err != nil {
+		return nil, err
+	}
+	return untypedNode[N, E]{Typed: node}, nil
+}
+
+func (ug untypedGraph[N, E]) GetEdge(from NodeID, to NodeID) (Edge, error) {
+	edge, err := ug.Typed.GetEdge(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return untypedEdge[N, E]{Typed: edge}, nil
+}
+
+func (ug untypedGraph[N, E]) GetEdgesBetween(from NodeID, to NodeID) ([]Edge, error) {
+	edges, err := ug.Typed.GetEdgesBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return untypeEdges(edges), nil
+}
+
+func (ug untypedGraph[N, E]) GetNodes() ([]Node, error) {
+	nodes, err := ug.Typed.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	return untypeNodes(nodes), nil
+}
+
+func (ug untypedGraph[N, E]) GetEdges() ([]Edge, error) {
+	edges, err := ug.Typed.GetEdges()
+	if err != nil {
+		return nil, err
+	}
+	return untypeEdges(edges), nil
+}
+
+func (ug untypedGraph[N, E]) IsDirected() bool {
+	return ug.Typed.IsDirected()
+}
+
+func (ug untypedGraph[N, E]) removeRefs() Graph {
+	return untypedGraph[N, E]{Typed: ug.Typed.removeRefs()}
+}
+
+type untypedNode[N, E any] struct {
+	Typed TypedNode[N, E]
+}
+
+func (un untypedNode[N, E]) GetID() NodeID {
+	return un.Typed.GetID()
+}
+
+func (un untypedNode[N, E]) GetIncomingEdges() ([]Edge, error) {
+	edges, err := un.Typed.GetIncomingEdges()
+	if err != nil {
+		return nil, err
+	}
+	return untypeEdges(edges), nil
+}
+
+func (un untypedNode[N, E]) GetOutgoingEdges() ([]Edge, error) {
+	edges, err := un.Typed.GetOutgoingEdges()
+	if err != nil {
+		return nil, err
+	}
+	return untypeEdges(edges), nil
+}
+
+func (un untypedNode[N, E]) GetIncidentEdges() ([]Edge, error) {
+	edges, err := un.Typed.GetIncidentEdges()
+	if err != nil {
+		return nil, err
+	}
+	return untypeEdges(edges), nil
+}
+
+func (un untypedNode[N, E]) GetValue() (interface{}, error) {
+	value, err := un.Typed.GetValue()
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (un untypedNode[N, E]) removeRef() Node {
+	return untypedNode[N, E]{Typed: un.Typed.removeRef()}
+}
+
+type untypedEdge[N, E any] struct {
+	Typed TypedEdge[N, E]
+}
+
+func (ue untypedEdge[N, E]) GetID() EdgeID {
+	return ue.Typed.GetID()
+}
+
+func (ue untypedEdge[N, E]) GetTo() (Node, error) {
+	node, err := ue.Typed.GetTo()
+	if err != nil {
+		return nil, err
+	}
+	return untypedNode[N, E]{Typed: node}, nil
+}
+
+func (ue untypedEdge[N, E]) GetFrom() (Node, error) {
+	node, err := ue.Typed.GetFrom()
+	if err != nil {
+		return nil, err
+	}
+	return untypedNode[N, E]{Typed: node}, nil
+}
+
+func (ue untypedEdge[N, E]) GetNodes() ([]Node, error) {
+	nodes, err := ue.Typed.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	return untypeNodes(nodes), nil
+}
+
+func (ue untypedEdge[N, E]) GetValue() (interface{}, error) {
+	value, err := ue.Typed.GetValue()
+	if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func (ue untypedEdge[N, E]) removeRef() Edge {
+	return untypedEdge[N, E]{Typed: ue.Typed.removeRef()}
+}
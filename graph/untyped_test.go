@@ -0,0 +1,71 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Untyped_GetValue(t *testing.T) {
+	gb := NewTypedGraphBuilder[string, int](BuilderOptions{IsDirected: true})
+	gb.AddNode(1, "one")
+	gb.AddNode(2)
+	gb.AddEdge(1, 2, 12)
+	typed, err := gb.Build()
+	assert.NoError(t, err)
+	graph := Untyped(typed)
+	assert.True(t, graph.IsDirected())
+
+	node, err := graph.GetNode(1)
+	assert.NoError(t, err)
+	actual_value, err := node.GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, "one", actual_value)
+
+	node, err = graph.GetNode(2)
+	assert.NoError(t, err)
+	_, err = node.GetValue()
+	assert.ErrorIs(t, err, NoValueFoundInNodeError{2})
+
+	incoming, err := node.GetIncomingEdges()
+	assert.NoError(t, err)
+	assert.Len(t, incoming, 1)
+	actual_value, err = incoming[0].GetValue()
+	assert.NoError(t, err)
+	assert.Equal(t, 12, actual_value)
+	from, err := incoming[0].GetFrom()
+	assert.NoError(t, err)
+	assert.Equal(t, NodeID(1), from.GetID())
+}
+
+func Test_Untyped_Kosaraju(t *testing.T) {
+	gb := NewTypedGraphBuilder[struct{}, string](BuilderOptions{IsDirected: true})
+	gb.AddNode(1)
+	gb.AddNode(2)
+	gb.AddNode(3)
+	gb.AddEdge(1, 2, "a")
+	gb.AddEdge(2, 1, "b")
+	gb.AddEdge(2, 3, "c")
+	typed, err := gb.Build()
+	assert.NoError(t, err)
+
+	sccs, err := Kosaraju(Untyped(typed))
+	assert.NoError(t, err)
+	actual_ids := make([][]NodeID, 0)
+	for _, scc := range sccs {
+		ids := make([]NodeID, 0)
+		for _, node := range scc {
+			ids = append(ids, node.GetID())
+		}
+		actual_ids = append(actual_ids, ids)
+	}
+	assert.Equal(t, [][]NodeID{{3}, {1, 2}}, actual_ids)
+}
+
+func Test_Untyped_ReturnsUntypedGraphAsIs(t *testing.T) {
+	gb := NewGraphBuilder()
+	gb.AddNode(1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, graph, Untyped(graph))
+}
@@ -0,0 +1,232 @@
+package graph
+
+// NodePredicate reports whether a node is part of a view.
+type NodePredicate func(Node) bool
+
+// EdgePredicate reports whether an edge is part of a view.
+type EdgePredicate func(Edge) bool
+
+// FilteredView returns a graph that only has the nodes of g accepted by keepNode
+// and the edges of g accepted by keepEdge whose endpoints are both in the view.
+// A nil predicate accepts everything.
+// The view reads through to g and calls the predicates on every access, so it does not copy any nodes or edges
+// and reflects later changes to g, such as edits to a MutableGraph.
+// Nodes and edges fetched from the view only lead to other nodes and edges of the view,
+// so every function taking a Graph works on it.
+func FilteredView(g Graph, keepNode NodePredicate, keepEdge EdgePredicate) Graph {
+	return filteredGraph{Base: g, KeepNode: keepNode, KeepEdge: keepEdge}
+}
+
+// InducedSubgraph returns a view of g with only the given nodes and the edges between them.
+// If one of the ids is not in g then this returns a "node not found" error.
+func InducedSubgraph(g Graph, ids []NodeID) (Graph, error) {
+	keep := make(map[NodeID]bool)
+	for _, id := range ids {
+		if _, err := g.GetNode(id); err != nil {
+			return nil, err
+		}
+		keep[id] = true
+	}
+	return FilteredView(g, func(n Node) bool {
+		return keep[n.GetID()]
+	}, nil), nil
+}
+
+type filteredGraph struct {
+	Base     Graph
+	KeepNode NodePredicate
+	KeepEdge EdgePredicate
+}
+
+func (fg filteredGraph) hasNode(node Node) bool {
+	return fg.KeepNode == nil || fg.KeepNode(node)
+}
+
+func (fg filteredGraph) hasNodeID(id NodeID) (bool, error) {
+	if fg.KeepNode == nil {
+		return true, nil
+	}
+	node, err := fg.Base.GetNode(id)
+	if err != nil {
+		return false, err
+	}
+	return fg.KeepNode(node), nil
+}
+
+func (fg filteredGraph) hasEdge(edge Edge) (bool, error) {
+	if fg.KeepEdge != nil && !fg.KeepEdge(edge) {
+		return false, nil
+	}
+	hasFrom, err := fg.hasNodeID(edge.GetID().From)
+	if err != nil || !hasFrom {
+		return false, err
+	}
+	return fg.hasNodeID(edge.GetID().To)
+}
+
+func (fg filteredGraph) filterNodes(nodes []Node) []Node {
+	filtered := make([]Node, 0)
+	for _, node := range nodes {
+		if fg.hasNode(node) {
+			filtered = append(filtered, filteredNode{Base: node, View: fg})
+		}
+	}
+	return filtered
+}
+
+func (fg filteredGraph) filterEdges(edges []Edge) ([]Edge, error) {
+	filtered := make([]Edge, 0)
+	for _, edge := range edges {
+		keep, err := fg.hasEdge(edge)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			filtered = append(filtered, filteredEdge{Base: edge, View: fg})
+		}
+	}
+	return filtered, nil
+}
+
+func (fg filteredGraph) GetNode(id NodeID) (Node, error) {
+	node, err := fg.Base.GetNode(id)
+	if err != nil {
+		return nil, err
+	}
+	if !fg.hasNode(node) {
+		return nil, NodeNotFoundError{NodeID: id}
+	}
+	return filteredNode{Base: node, View: fg}, nil
+}
+
+func (fg filteredGraph) GetEdge(from NodeID, to NodeID) (Edge, error) {
+	edges, err := fg.GetEdgesBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return edges[0], nil
+}
+
+func (fg filteredGraph) GetEdgesBetween(from NodeID, to NodeID) ([]Edge, error) {
+	edges, err := fg.Base.GetEdgesBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	filtered, err := fg.filterEdges(edges)
+	if err != nil {
+		return nil, err
+	}
+	if len(filtered) == 0 {
+		return nil, EdgeNotFoundError{FromID: from, ToID: to}
+	}
+	return filtered, nil
+}
+
+func (fg filteredGraph) GetNodes() ([]Node, error) {
+	nodes, err := fg.Base.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	return fg.filterNodes(nodes), nil
+}
+
+func (fg filteredGraph) GetEdges() ([]Edge, error) {
+	edges, err := fg.Base.GetEdges()
+	if err != nil {
+		return nil, err
+	}
+	return fg.filterEdges(edges)
+}
+
+func (fg filteredGraph) IsDirected() bool {
+	return fg.Base.IsDirected()
+}
+
+// removeRefs returns the view as is, since tests compare views through the Graph interface.
+func (fg filteredGraph) removeRefs() Graph {
+	return fg
+}
+
+type filteredNode struct {
+	Base Node
+	View filteredGraph
+}
+
+func (fn filteredNode) GetID() NodeID {
+	return fn.Base.GetID()
+}
+
+func (fn filteredNode) GetIncomingEdges() ([]Edge, error) {
+	edges, err := fn.Base.GetIncomingEdges()
+	if err != nil {
+		return nil, err
+	}
+	return fn.View.filterEdges(edges)
+}
+
+func (fn filteredNode) GetOutgoingEdges() ([]Edge, error) {
+	edges, err := fn.Base.GetOutgoingEdges()
+	if err != nil {
+		return nil, err
+	}
+	return fn.View.filterEdges(edges)
+}
+
+func (fn filteredNode) GetIncidentEdges() ([]Edge, error) {
+	edges, err := fn.Base.GetIncidentEdges()
+	if err != nil {
+		return nil, err
+	}
+	return fn.View.filterEdges(edges)
+}
+
+func (fn filteredNode) GetValue() (interface{}, error) {
+	return fn.Base.GetValue()
+}
+
+// removeRef returns the node as is, like filteredGraph.removeRefs.
+func (fn filteredNode) removeRef() Node {
+	return fn
+}
+
+type filteredEdge struct {
+	Base Edge
+	View filteredGraph
+}
+
+func (fe filteredEdge) GetID() EdgeID {
+	return fe.Base.GetID()
+}
+
+func (fe filteredEdge) GetTo() (Node, error) {
+	node, err := fe.Base.GetTo()
+	if err != nil {
+		return nil, err
+	}
+	return filteredNode{Base: node, View: fe.View}, nil
+}
+
+func (fe filteredEdge) GetFrom() (Node, error) {
+	node, err := fe.Base.GetFrom()
+	if err != nil {
+		return nil, err
+	}
+	return filteredNode{Base: node, View: fe.View}, nil
+}
+
+func (fe filteredEdge) GetNodes() ([]Node, error) {
+	nodes, err := fe.Base.GetNodes()
+	if err != nil {
+		return nil, err
+	}
+	return fe.View.filterNodes(nodes), nil
+}
+
+func (fe filteredEdge) GetValue() (interface{}, error) {
+	return fe.Base.GetValue()
+}
+
+// removeRef returns the edge as is, like filteredGraph.removeRefs.
+func (fe filteredEdge) removeRef() Edge {
+	return fe
+}
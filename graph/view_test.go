@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildOwnedGraph builds a directed graph whose edge values are the players owning them.
+func buildOwnedGraph(t *testing.T) Graph {
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 6; i++ {
+		gb.AddNode(NodeID(i), i%2 == 0)
+	}
+	gb.AddEdge(1, 2, 2)
+	gb.AddEdge(2, 1, 2)
+	gb.AddEdge(2, 3, 1)
+	gb.AddEdge(3, 4, 2)
+	gb.AddEdge(4, 3, 2)
+	gb.AddEdge(4, 5, 1)
+	graph, err := gb.Build()
+	assert.NoError(t, err)
+	return graph
+}
+
+func ownedBy(player int) EdgePredicate {
+	return func(e Edge) bool {
+		value, err := e.GetValue()
+		return err == nil && value == player
+	}
+}
+
+func Test_FilteredView_Edges(t *testing.T) {
+	graph := buildOwnedGraph(t)
+	view := FilteredView(graph, nil, ownedBy(2))
+	gb := NewGraphBuilder(BuilderOptions{IsDirected: true})
+	for i := 1; i < 6; i++ {
+		gb.AddNode(NodeID(i), i%2 == 0)
+	}
+	gb.AddEdge(1, 2, 2)
+	gb.AddEdge(2, 1, 2)
+	gb.AddEdge(3, 4, 2)
+	gb.AddEdge(4, 3, 2)
+	expected_graph, err := gb.Build()
+	assert.NoError(t, err)
+	AssertGraphEquals(t, expected_graph, view)
+
+	_, err = view.GetEdge(2, 3)
+	assert.ErrorIs(t, err, EdgeNotFoundError{FromID: 2, ToID: 3})
+	node, err := view.GetNode(2)
+	assert.NoError(t, err)
+	outgoing, err := node.GetOutgoingEdges()
+	assert.NoError(t, err)
+	assert.Len(t, outgoing, 1)
+
+	sccs, err := Kosaraju(view)
+	assert.NoError(t, err)
+	assert.Len(t, sccs, 3)
+	assert.Equal(t, []NodeID{5}, nodeIDs(sccs[0]))
+}
+
+func Test_FilteredView_Nodes(t *testing.T) {
+	graph := buildOwnedGraph(t)
+	isOdd := func(n Node) bool {
+		value, err := n.GetValue()
+		return err == nil && value == false
+	}
+	view := FilteredView(graph, isOdd, nil)
+	nodes, err := view.GetNodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{1, 3, 5}, nodeIDs(nodes))
+	edges, err := view.GetEdges()
+	assert.NoError(t, err)
+	assert.Empty(t, edges)
+	_, err = view.GetNode(2)
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 2})
+}
+
+func Test_InducedSubgraph(t *testing.T) {
+	graph := buildOwnedGraph(t)
+	subgraph, err := InducedSubgraph(graph, []NodeID{2, 3, 4})
+	assert.NoError(t, err)
+	edges, err := subgraph.GetEdges()
+	assert.NoError(t, err)
+	actual_edges := make([]EdgeID, 0)
+	for _, edge := range edges {
+		actual_edges = append(actual_edges, edge.GetID())
+	}
+	assert.Equal(t, []EdgeID{{From: 2, To: 3}, {From: 3, To: 4}, {From: 4, To: 3}}, actual_edges)
+
+	// edges of the view only lead to nodes of the view
+	edge, err := subgraph.GetEdge(2, 3)
+	assert.NoError(t, err)
+	from, err := edge.GetFrom()
+	assert.NoError(t, err)
+	incoming, err := from.GetIncomingEdges()
+	assert.NoError(t, err)
+	assert.Empty(t, incoming)
+
+	visited := make([]NodeID, 0)
+	_, err = DepthFirstSearch(subgraph, DepthFirstSearchOptions{BeforeRecursion: recordNodes(&visited)})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeID{2, 3, 4}, visited)
+
+	_, err = InducedSubgraph(graph, []NodeID{1, 6})
+	assert.ErrorIs(t, err, NodeNotFoundError{NodeID: 6})
+}
+
+func Test_InducedSubgraph_ReadsThrough(t *testing.T) {
+	graph, err := Edit(buildOwnedGraph(t))
+	assert.NoError(t, err)
+	subgraph, err := InducedSubgraph(graph, []NodeID{1, 2})
+	assert.NoError(t, err)
+	assert.NoError(t, graph.RemoveEdge(2, 1))
+	edges, err := subgraph.GetEdges()
+	assert.NoError(t, err)
+	assert.Len(t, edges, 1)
+}
@@ -1,7 +1,6 @@
 package hexagon
 
 import (
-	"fmt"
 	"sort"
 
 	"github.com/r0ddy/conquer/graph"
@@ -25,6 +24,9 @@ type CornerGrid struct {
 	Corners                []Corner
 	HexCornerToCornerIndex map[int]map[int]map[Direction]int
 	Sides                  []Side
+	// Graph connects the corners along the sides of the hexagons.
+	// Node ids are indices into Corners and edge values are the angles of the sides.
+	Graph graph.TypedGraph[struct{}, Angle]
 }
 
 type VisitedMap map[int]map[int]map[Direction]bool
@@ -127,7 +129,7 @@ func GetCornerGrid(hexGrid HexagonGrid) CornerGrid {
 		}
 	}
 
-	builder := graph.NewGraphBuilder(graph.BuilderOptions{AllowDuplicateEdges: true})
+	builder := graph.NewTypedGraphBuilder[struct{}, Angle](graph.BuilderOptions{AllowDuplicateEdges: true})
 	for idx := range grid.Corners {
 		builder.AddNode(graph.NodeID(idx))
 	}
@@ -142,10 +144,10 @@ func GetCornerGrid(hexGrid HexagonGrid) CornerGrid {
 			}
 		}
 	}
-	graph, err := builder.Build()
+	cornerGraph, err := builder.Build()
 	if err == nil {
-		edges, err := graph.GetEdges()
-		fmt.Printf("%v", edges)
+		grid.Graph = cornerGraph
+		edges, err := cornerGraph.GetEdges()
 		if err == nil {
 			for _, edge := range edges {
 				side := Side{CornerIndices: make([]int, 0)}
@@ -155,7 +157,7 @@ func GetCornerGrid(hexGrid HexagonGrid) CornerGrid {
 					}
 				}
 				if angle, err := edge.GetValue(); err == nil {
-					side.Angle = Angle(fmt.Sprintf("%v", angle))
+					side.Angle = angle
 				}
 				grid.Sides = append(grid.Sides, side)
 			}
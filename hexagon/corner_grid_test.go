@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/r0ddy/conquer/graph"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -81,3 +82,15 @@ func Test_CornerGrid_GetCornerGrid(t *testing.T) {
 	}
 	AssertCornerGridEquals(t, expected_corner_grid, actual_corner_grid)
 }
+
+func Test_CornerGrid_LongestTrail(t *testing.T) {
+	builder := NewHexagonGridBuilder()
+	builder.AddHexagon(2, 3)
+	builder.AddHexagon(3, 3)
+	cornerGrid := GetCornerGrid(builder.Build())
+	// the only two corners with three sides are on the shared side, so one trail covers all 11 sides
+	trail, err := graph.LongestTrail(graph.Untyped(cornerGrid.Graph), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 11, trail.Length)
+	assert.Equal(t, len(cornerGrid.Sides), trail.Length)
+}